@@ -7,18 +7,28 @@
 package example1
 
 import (
-	"github.com/PivotLLM/MCPLaunchPad/global"
+	"embed"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+	"github.com/PivotLLM/MCPLaunchPad/rest"
 )
 
-// Ensure Config implements the global.ToolProvider interface.
-var _ global.ToolProvider = (*Config)(nil)
+// Ensure Config implements the mcptypes.ToolProvider interface.
+var _ mcptypes.ToolProvider = (*Config)(nil)
+
+// endpoints holds the declarative definition of the widget API tools
+//
+//go:embed widgets.yaml
+var endpoints embed.FS
 
 // Config serves as the package's object and holds configuration information
 type Config struct {
 	BaseURL    string
 	AuthHeader string
 	AuthKey    string
-	Logger     global.Logger
+	Logger     mcptypes.Logger
+
+	api *rest.Provider // Widget API tools, built from widgets.yaml
 }
 
 // Option defines a function type for configuration options
@@ -46,7 +56,7 @@ func WithAuthKey(authKey string) Option {
 }
 
 // WithLogger sets the logger
-func WithLogger(logger global.Logger) Option {
+func WithLogger(logger mcptypes.Logger) Option {
 	return func(c *Config) {
 		c.Logger = logger
 	}
@@ -58,5 +68,20 @@ func New(options ...Option) *Config {
 	for _, opt := range options {
 		opt(config)
 	}
+	if config.Logger == nil {
+		config.Logger = mcptypes.NoopLogger{}
+	}
+
+	// The widget API tools are generated from widgets.yaml by the rest provider
+	api, err := rest.NewFromFS(endpoints, "widgets.yaml",
+		rest.WithLogger(config.Logger),
+		rest.WithBaseURL(config.BaseURL),
+		rest.WithAuthHeader(config.AuthHeader, config.AuthKey),
+	)
+	if err != nil {
+		config.Logger.Errorf("Unable to load widget API endpoints: %v", err)
+	}
+	config.api = api
+
 	return config
 }
@@ -8,16 +8,16 @@ package example1
 import (
 	"fmt"
 
-	"github.com/PivotLLM/MCPLaunchPad/global"
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
 )
 
 // GreetingPrompt is an example
-func (c *Config) GreetingPrompt(options map[string]any) (string, global.Messages, error) {
+func (c *Config) GreetingPrompt(options map[string]any) (string, mcptypes.Messages, error) {
 	name, ok := options["name"].(string)
 	if !ok || name == "" {
 		name = "friend"
 	}
-	return "A friendly greeting", []global.Message{
+	return "A friendly greeting", []mcptypes.Message{
 		{
 			Role:    "assistant",
 			Content: fmt.Sprintf("Hello, %s! How can I help you today?", name),
@@ -10,11 +10,11 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/PivotLLM/MCPLaunchPad/global"
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
 )
 
 // ResourceHandler is a simple handler that returns a readme file
-func (c *Config) ResourceHandler(uri string, options map[string]any) (global.ResourceResponse, error) {
+func (c *Config) ResourceHandler(uri string, options map[string]any) (mcptypes.ResourceResponse, error) {
 
 	// Check if the URI is valid
 	if uri == "file:///home/readme.txt" {
@@ -30,7 +30,7 @@ func (c *Config) ResourceHandler(uri string, options map[string]any) (global.Res
 		}
 
 		// Return the readme content
-		return global.ResourceResponse{URI: uri, MIMEType: "text/plain", Content: msg}, nil
+		return mcptypes.ResourceResponse{URI: uri, MIMEType: "text/plain", Content: msg}, nil
 	}
 
 	if strings.HasPrefix(uri, "abc:///info/") {
@@ -39,14 +39,14 @@ func (c *Config) ResourceHandler(uri string, options map[string]any) (global.Res
 
 		// Check if the letter or number is valid
 		if len(letterOrNumber) != 1 {
-			return global.ResourceResponse{}, errors.New("invalid letter or number, one character only")
+			return mcptypes.ResourceResponse{}, errors.New("invalid letter or number, one character only")
 		}
 
 		// Build some content because this is an example
 		msg := fmt.Sprintf("This is information about '%s' because you asked and you're important to me", letterOrNumber)
-		return global.ResourceResponse{URI: uri, MIMEType: "text/plain", Content: msg}, nil
+		return mcptypes.ResourceResponse{URI: uri, MIMEType: "text/plain", Content: msg}, nil
 	}
 
 	// If the URI is not valid, return an error
-	return global.ResourceResponse{}, errors.New("invalid URI")
+	return mcptypes.ResourceResponse{}, errors.New("invalid URI")
 }
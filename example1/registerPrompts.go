@@ -6,20 +6,16 @@
 package example1
 
 import (
-	"github.com/PivotLLM/MCPLaunchPad/global"
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
 )
 
-func (c *Config) RegisterPrompts() []global.PromptDefinition {
-	return []global.PromptDefinition{
+func (c *Config) RegisterPrompts() []mcptypes.PromptDefinition {
+	return []mcptypes.PromptDefinition{
 		{
 			Name:        "greeting",
 			Description: "A friendly greeting prompt",
-			Parameters: []global.Parameter{
-				{
-					Name:        "name",
-					Description: "Name of the person to greet",
-					Required:    true,
-				},
+			Parameters: []*mcptypes.Parameter{
+				mcptypes.StringParam("name", "Name of the person to greet", true),
 			},
 			Handler: c.GreetingPrompt,
 		},
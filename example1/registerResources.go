@@ -6,7 +6,7 @@
 package example1
 
 import (
-	"github.com/PivotLLM/MCPLaunchPad/global"
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
 )
 
 // RegisterResources will be called by the MCP server. This is a very simplistic
@@ -14,8 +14,8 @@ import (
 // The MCP server is also capable of returning more than plain text, so users may wish
 // to expand this, or bypass this wrapper entirely and either import
 // "github.com/mark3labs/mcp-go/mcp" or implement resources within the mcpserver package.
-func (c *Config) RegisterResources() []global.ResourceDefinition {
-	return []global.ResourceDefinition{
+func (c *Config) RegisterResources() []mcptypes.ResourceDefinition {
+	return []mcptypes.ResourceDefinition{
 		{
 			Name:        "readme",
 			Description: "A readme file",
@@ -27,8 +27,8 @@ func (c *Config) RegisterResources() []global.ResourceDefinition {
 }
 
 // RegisterResourceTemplates will be called by the MCP server.
-func (c *Config) RegisterResourceTemplates() []global.ResourceTemplateDefinition {
-	return []global.ResourceTemplateDefinition{
+func (c *Config) RegisterResourceTemplates() []mcptypes.ResourceTemplateDefinition {
+	return []mcptypes.ResourceTemplateDefinition{
 		{
 			Name:        "ABC Data",
 			Description: "ABC data provides all the information you need on the alphabet",
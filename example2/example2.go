@@ -11,22 +11,22 @@ import (
 	"strings"
 	"time"
 
-	"github.com/PivotLLM/MCPLaunchPad/global"
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
 )
 
-// Ensure Config implements the global.ToolProvider interface.
-var _ global.ToolProvider = (*Config)(nil)
+// Ensure Config implements the mcptypes.ToolProvider interface.
+var _ mcptypes.ToolProvider = (*Config)(nil)
 
 // Config serves as the package's object and holds configuration information
 type Config struct {
-	Logger global.Logger
+	Logger mcptypes.Logger
 }
 
 // Option defines a function type for configuration options
 type Option func(*Config)
 
 // WithLogger sets the logger
-func WithLogger(logger global.Logger) Option {
+func WithLogger(logger mcptypes.Logger) Option {
 	return func(c *Config) {
 		c.Logger = logger
 	}
@@ -42,17 +42,13 @@ func New(options ...Option) *Config {
 }
 
 // RegisterTools will be called by the MCP server to obtain information and register the tools
-func (c *Config) RegisterTools() []global.ToolDefinition {
-	return []global.ToolDefinition{
+func (c *Config) RegisterTools() []mcptypes.ToolDefinition {
+	return []mcptypes.ToolDefinition{
 		{
 			Name:        "get_time",
 			Description: "Get the current time. Optionally set 'time_format' to '12' for 12-hour format or '24' for 24-hour format.",
-			Parameters: []global.Parameter{
-				{
-					Name:        "time_format",
-					Description: "Time format (12 or 24).",
-					Required:    false,
-				},
+			Parameters: []*mcptypes.Parameter{
+				mcptypes.StringParam("time_format", "Time format (12 or 24).", false),
 			},
 			Handler: c.GetTime,
 		},
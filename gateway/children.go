@@ -0,0 +1,33 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package gateway
+
+import "fmt"
+
+// ChildStatusURI is the URI of the resource that reports the supervision state of the child
+// servers (running, restarting, failed, ...) as JSON
+const ChildStatusURI = "launchpad://children/status"
+
+// NewChildServers creates a gateway that runs each child as a stdio MCP server subprocess and
+// re-exports its tools, resources and prompts, with its status at ChildStatusURI. Add it to
+// the server with mcpserver.WithProvider, which starts the children when the server starts
+// and stops them in Stop().
+func NewChildServers(children []Upstream, options ...Option) (*Gateway, error) {
+	all := []Option{WithStatusResource(ChildStatusURI)}
+	all = append(all, options...)
+	for _, child := range children {
+		if child.Transport != UpstreamStdio {
+			return nil, fmt.Errorf("child server '%s' must use the stdio transport", child.Name)
+		}
+		all = append(all, WithUpstream(child))
+	}
+
+	g, err := New(all...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid child server configuration: %w", err)
+	}
+	return g, nil
+}
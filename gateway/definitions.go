@@ -0,0 +1,293 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// toolDefinitions converts the upstream's tools to prefixed, filtered tool definitions that
+// forward calls to the upstream
+func (u *upstream) toolDefinitions() []mcptypes.ToolDefinition {
+	u.mu.RLock()
+	tools := u.tools
+	u.mu.RUnlock()
+
+	var definitions []mcptypes.ToolDefinition
+	for _, tool := range tools {
+		if !u.exported(tool.Name) {
+			continue
+		}
+		upstreamName := tool.Name
+		definitions = append(definitions, mcptypes.ToolDefinition{
+			Name:            u.config.Prefix + tool.Name,
+			Description:     tool.Description,
+			RawInputSchema:  inputSchema(tool),
+			RawOutputSchema: tool.RawOutputSchema,
+			Hints:           u.hints(tool),
+			// The upstream applies its own schema, so arguments are forwarded as sent
+			SkipValidation: true,
+			HandlerResult: func(ctx context.Context, _ mcptypes.ToolRequest, options map[string]any) (*mcptypes.ToolResult, error) {
+				return u.callTool(ctx, upstreamName, options)
+			},
+		})
+	}
+	return definitions
+}
+
+// resourceDefinitions converts the upstream's resources to resource definitions that read
+// from the upstream
+func (u *upstream) resourceDefinitions() []mcptypes.ResourceDefinition {
+	u.mu.RLock()
+	resources := u.resources
+	u.mu.RUnlock()
+
+	var definitions []mcptypes.ResourceDefinition
+	for _, resource := range resources {
+		if !u.exported(resource.Name) {
+			continue
+		}
+		definitions = append(definitions, mcptypes.ResourceDefinition{
+			URI:         resource.URI,
+			Name:        resource.Name,
+			Description: resource.Description,
+			MIMEType:       resource.MIMEType,
+			HandlerContext: u.readResource,
+		})
+	}
+	return definitions
+}
+
+// templateDefinitions converts the upstream's resource templates to template definitions
+// that read from the upstream
+func (u *upstream) templateDefinitions() []mcptypes.ResourceTemplateDefinition {
+	u.mu.RLock()
+	templates := u.templates
+	u.mu.RUnlock()
+
+	var definitions []mcptypes.ResourceTemplateDefinition
+	for _, resourceTemplate := range templates {
+		if !u.exported(resourceTemplate.Name) || resourceTemplate.URITemplate == nil {
+			continue
+		}
+		definitions = append(definitions, mcptypes.ResourceTemplateDefinition{
+			URITemplate: resourceTemplate.URITemplate.Raw(),
+			Name:        resourceTemplate.Name,
+			Description: resourceTemplate.Description,
+			MIMEType:       resourceTemplate.MIMEType,
+			HandlerContext: u.readResource,
+		})
+	}
+	return definitions
+}
+
+// promptDefinitions converts the upstream's prompts to prefixed, filtered prompt definitions
+// that render through the upstream
+func (u *upstream) promptDefinitions() []mcptypes.PromptDefinition {
+	u.mu.RLock()
+	prompts := u.prompts
+	u.mu.RUnlock()
+
+	var definitions []mcptypes.PromptDefinition
+	for _, prompt := range prompts {
+		if !u.exported(prompt.Name) {
+			continue
+		}
+		params := make([]*mcptypes.Parameter, 0, len(prompt.Arguments))
+		for _, argument := range prompt.Arguments {
+			params = append(params, mcptypes.StringParam(argument.Name, argument.Description, argument.Required))
+		}
+		upstreamName := prompt.Name
+		definitions = append(definitions, mcptypes.PromptDefinition{
+			Name:        u.config.Prefix + prompt.Name,
+			Description: prompt.Description,
+			Parameters:  params,
+			HandlerContext: func(ctx context.Context, options map[string]any) (string, mcptypes.Messages, error) {
+				return u.getPrompt(ctx, upstreamName, options)
+			},
+		})
+	}
+	return definitions
+}
+
+// callTool forwards a tool call to the upstream
+func (u *upstream) callTool(ctx context.Context, name string, arguments map[string]any) (*mcptypes.ToolResult, error) {
+	c, ctx, cancel, err := u.request(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = arguments
+	result, err := c.CallTool(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("upstream '%s': %w", u.config.Name, requestError(ctx, err))
+	}
+
+	converted := mcptypes.NewToolResult()
+	for _, content := range result.Content {
+		converted.WithContent(toContent(content))
+	}
+	converted.StructuredContent = result.StructuredContent
+	converted.IsError = result.IsError
+	return converted, nil
+}
+
+// readResource reads a resource from the upstream. Only text contents are supported.
+func (u *upstream) readResource(ctx context.Context, uri string, _ map[string]any) (mcptypes.ResourceResponse, error) {
+	c, ctx, cancel, err := u.request(ctx)
+	if err != nil {
+		return mcptypes.ResourceResponse{}, err
+	}
+	defer cancel()
+
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = uri
+	result, err := c.ReadResource(ctx, request)
+	if err != nil {
+		return mcptypes.ResourceResponse{}, fmt.Errorf("upstream '%s': %w", u.config.Name, requestError(ctx, err))
+	}
+
+	for _, content := range result.Contents {
+		if text, ok := content.(mcp.TextResourceContents); ok {
+			return mcptypes.ResourceResponse{URI: text.URI, MIMEType: text.MIMEType, Content: text.Text}, nil
+		}
+	}
+	return mcptypes.ResourceResponse{}, fmt.Errorf("upstream '%s' returned no text contents for %s", u.config.Name, uri)
+}
+
+// getPrompt renders a prompt through the upstream. Non-text message content is omitted.
+func (u *upstream) getPrompt(ctx context.Context, name string, options map[string]any) (string, mcptypes.Messages, error) {
+	c, ctx, cancel, err := u.request(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	defer cancel()
+
+	request := mcp.GetPromptRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = make(map[string]string, len(options))
+	for key, value := range options {
+		request.Params.Arguments[key] = fmt.Sprint(value)
+	}
+	result, err := c.GetPrompt(ctx, request)
+	if err != nil {
+		return "", nil, fmt.Errorf("upstream '%s': %w", u.config.Name, requestError(ctx, err))
+	}
+
+	messages := make(mcptypes.Messages, 0, len(result.Messages))
+	for _, message := range result.Messages {
+		if text, ok := message.Content.(mcp.TextContent); ok {
+			messages = append(messages, mcptypes.Message{Role: string(message.Role), Content: text.Text})
+		}
+	}
+	return result.Description, messages, nil
+}
+
+// exported reports whether a name passes the upstream's allow and deny filters
+func (u *upstream) exported(name string) bool {
+	if len(u.config.Allow) > 0 && !matchAny(u.config.Allow, name) {
+		return false
+	}
+	return !matchAny(u.config.Deny, name)
+}
+
+// hints merges the upstream's annotations with the configured overrides. Per-tool overrides
+// take precedence over upstream-wide overrides, which take precedence over the upstream.
+func (u *upstream) hints(tool mcp.Tool) *mcptypes.ToolHints {
+	hints := &mcptypes.ToolHints{
+		ReadOnlyHint:    tool.Annotations.ReadOnlyHint,
+		DestructiveHint: tool.Annotations.DestructiveHint,
+		IdempotentHint:  tool.Annotations.IdempotentHint,
+		OpenWorldHint:   tool.Annotations.OpenWorldHint,
+	}
+	for _, override := range []*mcptypes.ToolHints{u.config.Hints, u.config.ToolHints[tool.Name]} {
+		if override == nil {
+			continue
+		}
+		if override.ReadOnlyHint != nil {
+			hints.ReadOnlyHint = override.ReadOnlyHint
+		}
+		if override.DestructiveHint != nil {
+			hints.DestructiveHint = override.DestructiveHint
+		}
+		if override.IdempotentHint != nil {
+			hints.IdempotentHint = override.IdempotentHint
+		}
+		if override.OpenWorldHint != nil {
+			hints.OpenWorldHint = override.OpenWorldHint
+		}
+	}
+	return hints
+}
+
+// matchAny reports whether name matches any of the glob patterns
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPatterns verifies that the allow and deny patterns of an upstream are valid
+func checkPatterns(config Upstream) error {
+	for _, pattern := range append(append([]string(nil), config.Allow...), config.Deny...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("upstream '%s' has invalid pattern '%s': %w", config.Name, pattern, err)
+		}
+	}
+	return nil
+}
+
+// inputSchema returns a tool's input schema exactly as the upstream declared it. A tool
+// without one accepts any object.
+func inputSchema(tool mcp.Tool) json.RawMessage {
+	if tool.RawInputSchema == nil {
+		return json.RawMessage(`{"type":"object"}`)
+	}
+	return tool.RawInputSchema
+}
+
+// toContent converts an upstream content block to an mcptypes content block
+func toContent(content mcp.Content) mcptypes.Content {
+	switch c := content.(type) {
+	case mcp.TextContent:
+		return mcptypes.NewTextContent(c.Text)
+	case mcp.ImageContent:
+		data, _ := base64.StdEncoding.DecodeString(c.Data)
+		return mcptypes.NewImageContent(data, c.MIMEType)
+	case mcp.AudioContent:
+		data, _ := base64.StdEncoding.DecodeString(c.Data)
+		return mcptypes.NewAudioContent(data, c.MIMEType)
+	case mcp.ResourceLink:
+		return mcptypes.NewResourceLinkContent(c.URI, c.Name, c.Description, c.MIMEType)
+	case mcp.EmbeddedResource:
+		switch resource := c.Resource.(type) {
+		case mcp.TextResourceContents:
+			return mcptypes.NewEmbeddedTextResource(resource.URI, resource.MIMEType, resource.Text)
+		case mcp.BlobResourceContents:
+			data, _ := base64.StdEncoding.DecodeString(resource.Blob)
+			return mcptypes.NewEmbeddedBlobResource(resource.URI, resource.MIMEType, data)
+		}
+	}
+
+	// Unknown content is passed on as its JSON representation
+	data, _ := json.Marshal(content)
+	return mcptypes.NewTextContent(strings.TrimSpace(string(data)))
+}
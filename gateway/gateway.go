@@ -0,0 +1,405 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+// Package gateway provides a provider that connects to upstream MCP servers as a client
+// and re-exports their tools, resources and prompts through an MCPServer, so that agents
+// can reach many servers through one endpoint.
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// UpstreamTransport selects how the gateway connects to an upstream server
+type UpstreamTransport int
+
+const (
+	// UpstreamStdio runs the upstream server as a subprocess and talks to it over stdin/stdout
+	UpstreamStdio UpstreamTransport = iota
+	// UpstreamSSE connects to an upstream server's SSE endpoint
+	UpstreamSSE
+	// UpstreamHTTP connects to an upstream server's streamable HTTP endpoint
+	UpstreamHTTP
+)
+
+// Upstream configures one upstream MCP server
+type Upstream struct {
+	Name      string // Identifies the upstream in log messages; must be unique
+	Transport UpstreamTransport
+
+	// Stdio subprocess
+	Command string
+	Args    []string
+	Env     []string // Additional environment variables ("KEY=value")
+	Dir     string   // Working directory; empty uses the current directory
+
+	// SSE and streamable HTTP
+	URL     string
+	Headers map[string]string // Sent with every request, e.g. Authorization
+
+	// Re-export settings
+	Prefix    string                         // Prepended to tool and prompt names, e.g. "github_"
+	Allow     []string                       // Glob patterns (path.Match) of names to export; empty exports all
+	Deny      []string                       // Glob patterns of names to hide, checked after Allow
+	Hints     *mcptypes.ToolHints            // Hint overrides for every tool of this upstream
+	ToolHints map[string]*mcptypes.ToolHints // Hint overrides by upstream tool name
+}
+
+// State is the supervision state of an upstream
+type State string
+
+const (
+	StateStopped    State = "stopped"    // Not started, or the gateway was stopped
+	StateStarting   State = "starting"   // Connecting for the first time
+	StateRunning    State = "running"    // Connected
+	StateRestarting State = "restarting" // Disconnected or exited; waiting to reconnect
+	StateFailed     State = "failed"     // Gave up after too many consecutive failures
+)
+
+// Option defines a function type for configuring the Gateway
+type Option func(*Gateway)
+
+// Gateway is a tool, resource and prompt provider backed by upstream MCP servers. It keeps
+// a connection to each upstream, reconnecting with exponential backoff, and reports changes
+// (connects, disconnects and upstream list_changed notifications) through Watch, so the
+// MCPServer updates its lists and notifies clients.
+type Gateway struct {
+	logger         mcptypes.Logger
+	configs        []Upstream
+	upstreams      []*upstream
+	reconnectMin   time.Duration
+	reconnectMax   time.Duration
+	healthInterval time.Duration
+	requestTimeout time.Duration
+	maxRestarts    int
+	stopTimeout    time.Duration
+	statusURI      string
+
+	startOnce   sync.Once
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+	subMu       sync.Mutex
+	subscribers []chan struct{}
+}
+
+// Compile-time check that Gateway implements the dynamic and stoppable provider interfaces
+var (
+	_ mcptypes.DynamicToolProvider     = (*Gateway)(nil)
+	_ mcptypes.DynamicResourceProvider = (*Gateway)(nil)
+	_ mcptypes.DynamicPromptProvider   = (*Gateway)(nil)
+	_ mcptypes.StoppableProvider       = (*Gateway)(nil)
+)
+
+// WithLogger sets the logger
+func WithLogger(logger mcptypes.Logger) Option {
+	return func(g *Gateway) {
+		g.logger = logger
+	}
+}
+
+// WithUpstream adds an upstream server. It may be used more than once.
+func WithUpstream(upstream Upstream) Option {
+	return func(g *Gateway) {
+		g.configs = append(g.configs, upstream)
+	}
+}
+
+// WithReconnectBackoff sets the initial and maximum delay between reconnect attempts (default: 1s to 1m)
+func WithReconnectBackoff(initial, maximum time.Duration) Option {
+	return func(g *Gateway) {
+		g.reconnectMin = initial
+		g.reconnectMax = maximum
+	}
+}
+
+// WithHealthCheckInterval sets how often connected upstreams are pinged (default: 30s)
+func WithHealthCheckInterval(interval time.Duration) Option {
+	return func(g *Gateway) {
+		g.healthInterval = interval
+	}
+}
+
+// WithRequestTimeout sets the timeout for requests forwarded to upstreams (default: 60s)
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(g *Gateway) {
+		g.requestTimeout = timeout
+	}
+}
+
+// WithMaxRestarts sets how many consecutive failures an upstream may have before the gateway
+// gives up on it and reports it as failed. A connection that ends within the maximum reconnect
+// backoff counts as a failure. The default of 0 retries forever.
+func WithMaxRestarts(n int) Option {
+	return func(g *Gateway) {
+		g.maxRestarts = n
+	}
+}
+
+// WithStopTimeout sets how long a stdio upstream process is given to exit after its stdin is
+// closed, and again after SIGTERM, before it is killed (default: 2s)
+func WithStopTimeout(timeout time.Duration) Option {
+	return func(g *Gateway) {
+		g.stopTimeout = timeout
+	}
+}
+
+// WithStatusResource exports the state of every upstream as a JSON resource at the given URI
+func WithStatusResource(uri string) Option {
+	return func(g *Gateway) {
+		g.statusURI = uri
+	}
+}
+
+// New creates a gateway with the provided options. Upstreams are not connected until
+// Start is called, which the MCPServer does through Watch when it starts.
+func New(options ...Option) (*Gateway, error) {
+	g := &Gateway{
+		reconnectMin:   time.Second,
+		reconnectMax:   time.Minute,
+		healthInterval: 30 * time.Second,
+		requestTimeout: 60 * time.Second,
+		stopTimeout:    2 * time.Second,
+	}
+	for _, opt := range options {
+		opt(g)
+	}
+	if g.logger == nil {
+		g.logger = mcptypes.NoopLogger{}
+	}
+	if g.reconnectMin <= 0 || g.reconnectMax < g.reconnectMin {
+		return nil, fmt.Errorf("invalid reconnect backoff %s to %s", g.reconnectMin, g.reconnectMax)
+	}
+
+	names := make(map[string]bool)
+	for _, config := range g.configs {
+		if config.Name == "" {
+			return nil, fmt.Errorf("upstream has no name")
+		}
+		if names[config.Name] {
+			return nil, fmt.Errorf("upstream name '%s' is used more than once", config.Name)
+		}
+		names[config.Name] = true
+
+		switch config.Transport {
+		case UpstreamStdio:
+			if config.Command == "" {
+				return nil, fmt.Errorf("stdio upstream '%s' has no command", config.Name)
+			}
+		case UpstreamSSE, UpstreamHTTP:
+			if config.URL == "" {
+				return nil, fmt.Errorf("upstream '%s' has no URL", config.Name)
+			}
+		default:
+			return nil, fmt.Errorf("upstream '%s' has unknown transport %d", config.Name, config.Transport)
+		}
+		if err := checkPatterns(config); err != nil {
+			return nil, err
+		}
+
+		g.upstreams = append(g.upstreams, &upstream{gateway: g, config: config, state: StateStopped})
+	}
+	return g, nil
+}
+
+// Start connects to all upstreams in the background, starting the processes of stdio
+// upstreams, and keeps them connected until ctx is cancelled or Stop is called. It is called
+// by Watch; calling it again has no effect.
+func (g *Gateway) Start(ctx context.Context) {
+	g.startOnce.Do(func() {
+		ctx, g.cancel = context.WithCancel(ctx)
+		for _, u := range g.upstreams {
+			g.wg.Add(1)
+			go func() {
+				defer g.wg.Done()
+				u.run(ctx)
+			}()
+		}
+	})
+}
+
+// Stop disconnects from all upstreams and stops their processes, waiting until they have
+// exited or ctx is done
+func (g *Gateway) Stop(ctx context.Context) error {
+	g.startOnce.Do(func() {}) // Prevent a later Start
+	if g.cancel != nil {
+		g.cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for upstreams to stop: %w", ctx.Err())
+	}
+}
+
+// Watch implements the Dynamic*Provider interfaces. It starts the gateway and returns a
+// channel that receives whenever the exported tools, resources or prompts may have changed.
+func (g *Gateway) Watch(ctx context.Context) <-chan struct{} {
+	g.Start(ctx)
+
+	ch := make(chan struct{}, 1)
+	g.subMu.Lock()
+	g.subscribers = append(g.subscribers, ch)
+	g.subMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		g.subMu.Lock()
+		defer g.subMu.Unlock()
+		for i, sub := range g.subscribers {
+			if sub == ch {
+				g.subscribers = append(g.subscribers[:i], g.subscribers[i+1:]...)
+				break
+			}
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// notify signals all watchers that the exported definitions changed
+func (g *Gateway) notify() {
+	g.subMu.Lock()
+	defer g.subMu.Unlock()
+	for _, ch := range g.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// RegisterTools returns the tools of all connected upstreams. A tool named like one of an
+// earlier upstream is hidden.
+func (g *Gateway) RegisterTools() []mcptypes.ToolDefinition {
+	var tools []mcptypes.ToolDefinition
+	seen := make(map[string]string)
+	for _, u := range g.upstreams {
+		for _, tool := range u.toolDefinitions() {
+			if !g.claim(seen, "tool", tool.Name, u) {
+				continue
+			}
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// RegisterResources returns the resources of all connected upstreams, and the status resource
+// if enabled. Resource URIs are not prefixed, so a resource with the URI of the status
+// resource or of a resource of an earlier upstream is hidden.
+func (g *Gateway) RegisterResources() []mcptypes.ResourceDefinition {
+	var resources []mcptypes.ResourceDefinition
+	seen := make(map[string]string)
+	if g.statusURI != "" {
+		resources = append(resources, g.statusResource())
+		seen[g.statusURI] = "the status resource"
+	}
+	for _, u := range g.upstreams {
+		for _, resource := range u.resourceDefinitions() {
+			if !g.claim(seen, "resource", resource.URI, u) {
+				continue
+			}
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
+
+// RegisterResourceTemplates returns the resource templates of all connected upstreams. A
+// template with the same URI template as one of an earlier upstream is hidden.
+func (g *Gateway) RegisterResourceTemplates() []mcptypes.ResourceTemplateDefinition {
+	var templates []mcptypes.ResourceTemplateDefinition
+	seen := make(map[string]string)
+	for _, u := range g.upstreams {
+		for _, resourceTemplate := range u.templateDefinitions() {
+			if !g.claim(seen, "resource template", resourceTemplate.URITemplate, u) {
+				continue
+			}
+			templates = append(templates, resourceTemplate)
+		}
+	}
+	return templates
+}
+
+// RegisterPrompts returns the prompts of all connected upstreams. A prompt named like one of
+// an earlier upstream is hidden.
+func (g *Gateway) RegisterPrompts() []mcptypes.PromptDefinition {
+	var prompts []mcptypes.PromptDefinition
+	seen := make(map[string]string)
+	for _, u := range g.upstreams {
+		for _, prompt := range u.promptDefinitions() {
+			if !g.claim(seen, "prompt", prompt.Name, u) {
+				continue
+			}
+			prompts = append(prompts, prompt)
+		}
+	}
+	return prompts
+}
+
+// claim records that the upstream offers the named tool, resource or prompt, and reports
+// false with a warning if an earlier upstream already offers it
+func (g *Gateway) claim(seen map[string]string, kind, name string, u *upstream) bool {
+	if owner, exists := seen[name]; exists {
+		g.logger.Warningf("Gateway: %s '%s' from upstream '%s' hidden by %s", kind, name, u.config.Name, owner)
+		return false
+	}
+	seen[name] = fmt.Sprintf("upstream '%s'", u.config.Name)
+	return true
+}
+
+// UpstreamStatus describes the connection state of an upstream
+type UpstreamStatus struct {
+	Name      string    `json:"name"`
+	State     State     `json:"state"`
+	Since     time.Time `json:"since"` // When the upstream entered its current state
+	Restarts  int       `json:"restarts"`
+	PID       int       `json:"pid,omitempty"` // Process ID of a running stdio upstream
+	Connected bool      `json:"connected"`
+	LastError string    `json:"lastError,omitempty"`
+	Tools     int       `json:"tools"`
+	Resources int       `json:"resources"`
+	Prompts   int       `json:"prompts"`
+}
+
+// Status returns the connection state of every upstream, in configuration order
+func (g *Gateway) Status() []UpstreamStatus {
+	status := make([]UpstreamStatus, 0, len(g.upstreams))
+	for _, u := range g.upstreams {
+		status = append(status, u.status())
+	}
+	return status
+}
+
+// statusResource returns the resource that reports Status as JSON
+func (g *Gateway) statusResource() mcptypes.ResourceDefinition {
+	return mcptypes.ResourceDefinition{
+		Name:        "Upstream status",
+		Description: "Supervision state of the upstream MCP servers",
+		MIMEType:    "application/json",
+		URI:         g.statusURI,
+		Handler: func(uri string, options map[string]any) (mcptypes.ResourceResponse, error) {
+			data, err := json.MarshalIndent(g.Status(), "", "  ")
+			if err != nil {
+				return mcptypes.ResourceResponse{}, err
+			}
+			return mcptypes.ResourceResponse{URI: uri, MIMEType: "application/json", Content: string(data)}, nil
+		},
+	}
+}
@@ -0,0 +1,226 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// The schemas of the echo tool use keywords that mcp-go's schema struct does not keep
+const (
+	echoInputSchema = `{"type":"object","properties":{"count":{"type":"integer","default":1},` +
+		`"value":{"anyOf":[{"type":"string"},{"$ref":"#/$defs/pair"}]}},` +
+		`"$defs":{"pair":{"type":"array","items":{"type":"number"},"minItems":2,"maxItems":2}},` +
+		`"additionalProperties":false}`
+	echoOutputSchema = `{"type":"object","properties":{"arguments":{"type":"object"}},"additionalProperties":false}`
+)
+
+// newUpstreamServer starts a streamable HTTP upstream offering an echo tool and enough other
+// tools that listing them takes several pages
+func newUpstreamServer(t *testing.T) string {
+	t.Helper()
+	s := server.NewMCPServer("upstream", "1.0.0", server.WithPaginationLimit(2))
+	echo := mcp.NewToolWithRawSchema("echo", "Echoes its arguments", json.RawMessage(echoInputSchema))
+	echo.RawOutputSchema = json.RawMessage(echoOutputSchema)
+	s.AddTool(echo, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		data, err := json.Marshal(req.GetArguments())
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(string(data)), nil
+	})
+	for i := range 4 {
+		s.AddTool(mcp.NewTool(fmt.Sprintf("tool%d", i)), func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		})
+	}
+	ts := server.NewTestStreamableHTTPServer(s)
+	t.Cleanup(ts.Close)
+	return ts.URL + "/mcp"
+}
+
+// startGateway starts a gateway for the upstream and waits until it is connected
+func startGateway(t *testing.T, url string) *Gateway {
+	t.Helper()
+	g, err := New(WithUpstream(Upstream{Name: "up", Transport: UpstreamHTTP, URL: url, Prefix: "up_"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		_ = g.Stop(context.Background())
+	})
+
+	changes := g.Watch(ctx)
+	timeout := time.After(5 * time.Second)
+	for g.Status()[0].State != StateRunning {
+		select {
+		case <-changes:
+		case <-timeout:
+			t.Fatalf("upstream not connected: %+v", g.Status())
+		}
+	}
+	return g
+}
+
+func TestGatewayListsEveryPage(t *testing.T) {
+	g := startGateway(t, newUpstreamServer(t))
+
+	var names []string
+	for _, tool := range g.RegisterTools() {
+		names = append(names, tool.Name)
+	}
+	want := []string{"up_echo", "up_tool0", "up_tool1", "up_tool2", "up_tool3"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("tools = %v, want %v", names, want)
+	}
+}
+
+func TestGatewayPassesSchemasThrough(t *testing.T) {
+	g := startGateway(t, newUpstreamServer(t))
+
+	for _, tool := range g.RegisterTools() {
+		if tool.Name != "up_echo" {
+			continue
+		}
+		assertJSON(t, "input schema", tool.RawInputSchema, echoInputSchema)
+		assertJSON(t, "output schema", tool.RawOutputSchema, echoOutputSchema)
+		if !tool.SkipValidation || tool.Parameters != nil {
+			t.Error("gateway tool is checked against converted parameters")
+		}
+		return
+	}
+	t.Fatal("echo tool not exported")
+}
+
+func TestGatewayForwardsArgumentsUnchanged(t *testing.T) {
+	g := startGateway(t, newUpstreamServer(t))
+
+	arguments := map[string]any{"count": "2", "value": []any{1.5, 2.5}}
+	result, err := g.upstreams[0].callTool(context.Background(), "echo", arguments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Content) != 1 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	assertJSON(t, "forwarded arguments", json.RawMessage(result.Content[0].Text), `{"count":"2","value":[1.5,2.5]}`)
+}
+
+func TestListAllRejectsRepeatedCursor(t *testing.T) {
+	pages := map[mcp.Cursor]mcp.Cursor{"": "a", "a": "b", "b": "a"}
+	_, err := listAll(context.Background(), func(_ context.Context, cursor mcp.Cursor) ([]int, mcp.Cursor, error) {
+		return []int{1}, pages[cursor], nil
+	})
+	if err == nil {
+		t.Fatal("listAll followed a cursor loop")
+	}
+}
+
+func TestGatewayHidesDuplicates(t *testing.T) {
+	files := mcp.NewResourceTemplate("file:///{path}", "files")
+	first := &upstream{config: Upstream{Name: "first", Prefix: "fs_"}}
+	first.resources = []mcp.Resource{mcp.NewResource("file:///a", "a"), mcp.NewResource("gateway://status", "status")}
+	first.templates = []mcp.ResourceTemplate{files}
+	first.prompts = []mcp.Prompt{mcp.NewPrompt("review")}
+	second := &upstream{config: Upstream{Name: "second", Prefix: "fs_"}}
+	second.resources = []mcp.Resource{mcp.NewResource("file:///a", "a"), mcp.NewResource("file:///b", "b")}
+	second.templates = []mcp.ResourceTemplate{files, mcp.NewResourceTemplate("db:///{table}", "tables")}
+	second.prompts = []mcp.Prompt{mcp.NewPrompt("review"), mcp.NewPrompt("summary")}
+	g := &Gateway{logger: mcptypes.NoopLogger{}, upstreams: []*upstream{first, second}, statusURI: "gateway://status"}
+
+	var resources, templates, prompts []string
+	for _, resource := range g.RegisterResources() {
+		resources = append(resources, resource.Name)
+	}
+	for _, resourceTemplate := range g.RegisterResourceTemplates() {
+		templates = append(templates, resourceTemplate.Name)
+	}
+	for _, prompt := range g.RegisterPrompts() {
+		prompts = append(prompts, prompt.Name)
+	}
+	for _, check := range []struct {
+		kind      string
+		got, want []string
+	}{
+		{"resources", resources, []string{"Upstream status", "a", "b"}},
+		{"templates", templates, []string{"files", "tables"}},
+		{"prompts", prompts, []string{"fs_review", "fs_summary"}},
+	} {
+		if !reflect.DeepEqual(check.got, check.want) {
+			t.Errorf("%s = %v, want %v", check.kind, check.got, check.want)
+		}
+	}
+}
+
+func TestGatewayPassesRequestContext(t *testing.T) {
+	s := server.NewMCPServer("upstream", "1.0.0")
+	s.AddResource(mcp.NewResource("test://slow", "slow"), func(ctx context.Context, _ mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		select {
+		case <-ctx.Done():
+		case <-time.After(5 * time.Second):
+		}
+		return []mcp.ResourceContents{mcp.TextResourceContents{URI: "test://slow", Text: "late"}}, nil
+	})
+	ts := server.NewTestStreamableHTTPServer(s)
+	t.Cleanup(ts.Close)
+	g := startGateway(t, ts.URL+"/mcp")
+
+	resources := g.RegisterResources()
+	if len(resources) != 1 || resources[0].HandlerContext == nil {
+		t.Fatalf("resources = %+v", resources)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := resources[0].HandlerContext(ctx, "test://slow", nil); err == nil {
+		t.Error("read succeeded after the caller's deadline")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("read returned after %v, not at the caller's deadline", elapsed)
+	}
+}
+
+// assertJSON checks that got holds the same JSON value as want
+func assertJSON(t *testing.T, what string, got json.RawMessage, want string) {
+	t.Helper()
+	var gotValue, wantValue any
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("%s is not JSON: %v", what, err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("%s = %s, want %s", what, got, want)
+	}
+}
+
+func TestNewChildServers(t *testing.T) {
+	if _, err := NewChildServers([]Upstream{{Name: "remote", Transport: UpstreamHTTP, URL: "http://localhost"}}); err == nil {
+		t.Error("accepted a child server that is not run over stdio")
+	}
+
+	g, err := NewChildServers([]Upstream{{Name: "child", Command: "true"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resources := g.RegisterResources()
+	if len(resources) != 1 || resources[0].URI != ChildStatusURI {
+		t.Errorf("resources = %+v, want only the status resource", resources)
+	}
+}
@@ -0,0 +1,133 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+)
+
+// process is a stdio upstream server running as a child process. The gateway owns the
+// process rather than leaving it to the mcp-go transport, so it can notice a crash as soon
+// as it happens and stop the process gracefully.
+type process struct {
+	cmd    *exec.Cmd
+	stdin  *os.File
+	stdout *os.File
+	exited chan struct{} // Closed when the process has exited
+	err    error         // Exit status, valid once exited is closed
+}
+
+// startProcess starts the upstream command with its arguments, environment and working
+// directory, and forwards its stderr to the logger
+func (u *upstream) startProcess() (*process, error) {
+	cmd := exec.Command(u.config.Command, u.config.Args...)
+	cmd.Env = append(os.Environ(), u.config.Env...)
+	cmd.Dir = u.config.Dir
+	setProcessGroup(cmd)
+
+	// Plain pipes rather than cmd.StdinPipe() and friends, which Wait closes while the
+	// transport may still be reading the last response
+	stdinReader, stdin, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create stdin pipe: %w", err)
+	}
+	stdout, stdoutWriter, err := os.Pipe()
+	if err != nil {
+		closeAll(stdinReader, stdin)
+		return nil, fmt.Errorf("unable to create stdout pipe: %w", err)
+	}
+	stderr, stderrWriter, err := os.Pipe()
+	if err != nil {
+		closeAll(stdinReader, stdin, stdout, stdoutWriter)
+		return nil, fmt.Errorf("unable to create stderr pipe: %w", err)
+	}
+	cmd.Stdin = stdinReader
+	cmd.Stdout = stdoutWriter
+	cmd.Stderr = stderrWriter
+
+	err = cmd.Start()
+	closeAll(stdinReader, stdoutWriter, stderrWriter) // The child has its own copies
+	if err != nil {
+		closeAll(stdin, stdout, stderr)
+		return nil, fmt.Errorf("unable to start command: %w", err)
+	}
+
+	p := &process{cmd: cmd, stdin: stdin, stdout: stdout, exited: make(chan struct{})}
+	go func() {
+		p.err = cmd.Wait()
+		close(p.exited)
+	}()
+
+	// Reading stderr also keeps the process from blocking on a full pipe
+	go func() {
+		defer func() { _ = stderr.Close() }()
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			u.gateway.logger.Infof("Gateway: [%s] %s", u.config.Name, scanner.Text())
+		}
+	}()
+
+	u.gateway.logger.Infof("Gateway: started upstream '%s' (pid %d)", u.config.Name, cmd.Process.Pid)
+	return p, nil
+}
+
+// closeAll closes files, ignoring errors
+func closeAll(files ...*os.File) {
+	for _, f := range files {
+		_ = f.Close()
+	}
+}
+
+// transport returns an mcp-go transport that talks to the process over its stdin and stdout
+func (p *process) transport() transport.Interface {
+	return transport.NewIO(p.stdout, p.stdin, nil)
+}
+
+// pid returns the process ID
+func (p *process) pid() int {
+	return p.cmd.Process.Pid
+}
+
+// stop asks the process to exit by closing its stdin, then sends SIGTERM, and finally kills
+// it, waiting up to timeout after each step. The signals go to the whole process group, so
+// commands run through a wrapper such as npx or a shell script are stopped too. Stdout is
+// left open for the transport, which reads it to EOF.
+func (p *process) stop(timeout time.Duration) error {
+	_ = p.stdin.Close()
+	if p.waitExit(timeout) {
+		return nil
+	}
+
+	// Signals other than Kill are not supported on Windows; fall through to Kill
+	if err := p.signal(syscall.SIGTERM); err == nil && p.waitExit(timeout) {
+		return nil
+	}
+
+	if err := p.signal(syscall.SIGKILL); err != nil {
+		return fmt.Errorf("unable to kill process %d: %w", p.pid(), err)
+	}
+	<-p.exited
+	return fmt.Errorf("process %d did not exit within %s and was killed", p.pid(), 2*timeout)
+}
+
+// waitExit reports whether the process exits within timeout
+func (p *process) waitExit(timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-p.exited:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !unix
+
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup does nothing on platforms without process groups
+func setProcessGroup(*exec.Cmd) {}
+
+// signal sends a signal to the process only, since there is no process group to signal
+func (p *process) signal(sig syscall.Signal) error {
+	if sig == syscall.SIGKILL {
+		return p.cmd.Process.Kill()
+	}
+	return p.cmd.Process.Signal(sig)
+}
@@ -0,0 +1,42 @@
+//go:build unix
+
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+func TestStopSignalsProcessGroup(t *testing.T) {
+	// The backgrounded subshell ignores stdin and is not the process started, like the server
+	// started by a wrapper script. It holds stdout open until it exits.
+	u := &upstream{
+		gateway: &Gateway{logger: mcptypes.NoopLogger{}},
+		config:  Upstream{Name: "wrapper", Command: "sh", Args: []string{"-c", "(sleep 60; echo late) & exec sleep 60"}},
+	}
+	p, err := u.startProcess()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = p.stdout.Close() }()
+
+	if err = p.stop(100 * time.Millisecond); err != nil {
+		t.Errorf("stop() = %v", err)
+	}
+
+	// Stdout reaches EOF once every process holding it has exited
+	if err = p.stdout.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = io.ReadAll(p.stdout); err != nil {
+		t.Fatalf("process group still running after stop: %v", err)
+	}
+}
@@ -0,0 +1,494 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// upstream holds the connection to one upstream server and the definitions it last reported
+type upstream struct {
+	gateway *Gateway
+	config  Upstream
+
+	mu        sync.RWMutex
+	client    *client.Client
+	connCtx   context.Context // Cancelled when the connection of client ends
+	proc      *process
+	caps      mcp.ServerCapabilities
+	tools     []mcp.Tool
+	resources []mcp.Resource
+	templates []mcp.ResourceTemplate
+	prompts   []mcp.Prompt
+	state     State
+	since     time.Time
+	restarts  int
+	lastErr   error
+	requests  atomic.Int64 // Sequence for the IDs of requests sent through the transport
+}
+
+// run keeps the upstream connected until ctx is cancelled, reconnecting with exponential
+// backoff. A connection that ends within the maximum backoff counts as a failure, so a
+// server that crashes on startup is not restarted in a tight loop.
+func (u *upstream) run(ctx context.Context) {
+	g := u.gateway
+	backoff := g.reconnectMin
+	failures := 0
+	u.setState(StateStarting)
+	for {
+		c, err := u.connect(ctx)
+		if err == nil {
+			connected := time.Now()
+			g.logger.Infof("Gateway: connected to upstream '%s'", u.config.Name)
+			err = u.serve(ctx, c)
+			u.disconnect(err)
+			_ = c.Close()
+			if time.Since(connected) >= g.reconnectMax {
+				backoff = g.reconnectMin
+				failures = 0
+			}
+		} else {
+			u.setError(err)
+		}
+		u.stopProcess()
+
+		if ctx.Err() != nil {
+			u.setState(StateStopped)
+			return
+		}
+
+		failures++
+		if g.maxRestarts > 0 && failures > g.maxRestarts {
+			g.logger.Errorf("Gateway: upstream '%s' failed %d times in a row, giving up: %v", u.config.Name, failures, err)
+			u.setState(StateFailed)
+			return
+		}
+		g.logger.Warningf("Gateway: upstream '%s' unavailable, retrying in %s: %v", u.config.Name, backoff, err)
+		u.setState(StateRestarting)
+
+		select {
+		case <-ctx.Done():
+			u.setState(StateStopped)
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, g.reconnectMax)
+
+		u.mu.Lock()
+		u.restarts++
+		u.mu.Unlock()
+	}
+}
+
+// connect creates a client for the upstream, starting its process for stdio upstreams,
+// and completes the initialize handshake
+func (u *upstream) connect(ctx context.Context) (*client.Client, error) {
+	var c *client.Client
+	var err error
+	logger := u.gateway.logger
+	switch u.config.Transport {
+	case UpstreamStdio:
+		var p *process
+		if p, err = u.startProcess(); err == nil {
+			u.mu.Lock()
+			u.proc = p
+			u.mu.Unlock()
+			c = client.NewClient(p.transport())
+		}
+	case UpstreamSSE:
+		c, err = client.NewSSEMCPClient(u.config.URL,
+			transport.WithHeaders(u.config.Headers),
+			transport.WithSSELogger(logger))
+	default:
+		// Continuous listening opens the GET stream on which the upstream sends list_changed
+		c, err = client.NewStreamableHttpClient(u.config.URL,
+			transport.WithHTTPHeaders(u.config.Headers),
+			transport.WithHTTPLogger(logger),
+			transport.WithContinuousListening())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client: %w", err)
+	}
+
+	// The transport lives as long as ctx; only the handshake is bounded by the request timeout
+	if err = c.Start(ctx); err != nil {
+		_ = c.Close()
+		return nil, fmt.Errorf("unable to start client: %w", err)
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx, u.gateway.requestTimeout)
+	defer cancel()
+
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "MCPLaunchPad-gateway", Version: "1.0.0"}
+	result, err := c.Initialize(requestCtx, initRequest)
+	if err != nil {
+		_ = c.Close()
+		return nil, fmt.Errorf("initialize failed: %w", err)
+	}
+
+	u.mu.Lock()
+	u.caps = result.Capabilities
+	u.mu.Unlock()
+	return c, nil
+}
+
+// serve publishes the upstream's definitions and forwards its list_changed notifications
+// until the connection is lost or ctx is cancelled. It returns the reason the connection ended.
+func (u *upstream) serve(ctx context.Context, c *client.Client) error {
+	connCtx, connCancel := context.WithCancel(ctx)
+	defer connCancel()
+
+	lost := make(chan error, 1)
+	c.OnConnectionLost(func(err error) {
+		select {
+		case lost <- err:
+		default:
+		}
+	})
+
+	changes := make(chan string, 8)
+	c.OnNotification(func(notification mcp.JSONRPCNotification) {
+		switch notification.Method {
+		case mcp.MethodNotificationToolsListChanged,
+			mcp.MethodNotificationResourcesListChanged,
+			mcp.MethodNotificationPromptsListChanged:
+			select {
+			case changes <- notification.Method:
+			default:
+			}
+		}
+	})
+
+	if err := u.refresh(ctx, c, ""); err != nil {
+		return err
+	}
+	u.mu.Lock()
+	u.client = c
+	u.connCtx = connCtx
+	u.lastErr = nil
+	u.state = StateRunning
+	u.since = time.Now()
+	u.mu.Unlock()
+	u.gateway.notify()
+
+	// A stdio upstream's connection is lost when its process exits
+	var exited <-chan struct{}
+	u.mu.RLock()
+	p := u.proc
+	u.mu.RUnlock()
+	if p != nil {
+		exited = p.exited
+	}
+
+	var health <-chan time.Time
+	if u.gateway.healthInterval > 0 {
+		ticker := time.NewTicker(u.gateway.healthInterval)
+		defer ticker.Stop()
+		health = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-lost:
+			return fmt.Errorf("connection lost: %w", err)
+		case <-exited:
+			if p.err != nil {
+				return fmt.Errorf("process exited: %w", p.err)
+			}
+			return fmt.Errorf("process exited")
+		case method := <-changes:
+			if err := u.refresh(ctx, c, method); err != nil {
+				return err
+			}
+			u.gateway.notify()
+		case <-health:
+			pingCtx, cancel := context.WithTimeout(ctx, u.gateway.requestTimeout)
+			err := c.Ping(pingCtx)
+			cancel()
+			if err != nil {
+				return fmt.Errorf("health check failed: %w", err)
+			}
+		}
+	}
+}
+
+// refresh fetches the lists named by a list_changed notification method, or all lists the
+// upstream supports if method is empty
+func (u *upstream) refresh(ctx context.Context, c *client.Client, method string) error {
+	ctx, cancel := context.WithTimeout(ctx, u.gateway.requestTimeout)
+	defer cancel()
+
+	u.mu.RLock()
+	caps := u.caps
+	u.mu.RUnlock()
+
+	if caps.Tools != nil && (method == "" || method == mcp.MethodNotificationToolsListChanged) {
+		tools, err := listAll(ctx, func(ctx context.Context, cursor mcp.Cursor) ([]mcp.Tool, mcp.Cursor, error) {
+			return u.listToolsPage(ctx, c, cursor)
+		})
+		if err != nil {
+			return fmt.Errorf("unable to list tools: %w", err)
+		}
+		u.mu.Lock()
+		u.tools = tools
+		u.mu.Unlock()
+	}
+
+	if caps.Resources != nil && (method == "" || method == mcp.MethodNotificationResourcesListChanged) {
+		resources, err := listAll(ctx, func(ctx context.Context, cursor mcp.Cursor) ([]mcp.Resource, mcp.Cursor, error) {
+			request := mcp.ListResourcesRequest{}
+			request.Params.Cursor = cursor
+			result, err := c.ListResourcesByPage(ctx, request)
+			if err != nil {
+				return nil, "", err
+			}
+			return result.Resources, result.NextCursor, nil
+		})
+		if err != nil {
+			return fmt.Errorf("unable to list resources: %w", err)
+		}
+		templates, err := listAll(ctx, func(ctx context.Context, cursor mcp.Cursor) ([]mcp.ResourceTemplate, mcp.Cursor, error) {
+			request := mcp.ListResourceTemplatesRequest{}
+			request.Params.Cursor = cursor
+			result, err := c.ListResourceTemplatesByPage(ctx, request)
+			if err != nil {
+				return nil, "", err
+			}
+			return result.ResourceTemplates, result.NextCursor, nil
+		})
+		if err != nil {
+			return fmt.Errorf("unable to list resource templates: %w", err)
+		}
+		u.mu.Lock()
+		u.resources = resources
+		u.templates = templates
+		u.mu.Unlock()
+	}
+
+	if caps.Prompts != nil && (method == "" || method == mcp.MethodNotificationPromptsListChanged) {
+		prompts, err := listAll(ctx, func(ctx context.Context, cursor mcp.Cursor) ([]mcp.Prompt, mcp.Cursor, error) {
+			request := mcp.ListPromptsRequest{}
+			request.Params.Cursor = cursor
+			result, err := c.ListPromptsByPage(ctx, request)
+			if err != nil {
+				return nil, "", err
+			}
+			return result.Prompts, result.NextCursor, nil
+		})
+		if err != nil {
+			return fmt.Errorf("unable to list prompts: %w", err)
+		}
+		u.mu.Lock()
+		u.prompts = prompts
+		u.mu.Unlock()
+	}
+
+	status := u.status()
+	u.gateway.logger.Debugf("Gateway: upstream '%s' has %d tools, %d resources and %d prompts",
+		u.config.Name, status.Tools, status.Resources, status.Prompts)
+	return nil
+}
+
+// listToolsPage fetches one page of the upstream's tools. The client decodes tool schemas
+// into a struct that keeps only type, properties, required and $defs, so the page is
+// requested through the transport and each tool keeps the schemas exactly as sent.
+func (u *upstream) listToolsPage(ctx context.Context, c *client.Client, cursor mcp.Cursor) ([]mcp.Tool, mcp.Cursor, error) {
+	request := mcp.ListToolsRequest{}
+	request.Params.Cursor = cursor
+
+	// String IDs cannot collide with the numeric IDs the client assigns
+	response, err := c.GetTransport().SendRequest(ctx, transport.JSONRPCRequest{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		ID:      mcp.NewRequestId(fmt.Sprintf("gateway-%d", u.requests.Add(1))),
+		Method:  string(mcp.MethodToolsList),
+		Params:  request.Params,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if response.Error != nil {
+		return nil, "", response.Error.AsError()
+	}
+
+	var page struct {
+		Tools      []json.RawMessage `json:"tools"`
+		NextCursor mcp.Cursor        `json:"nextCursor"`
+	}
+	if err = json.Unmarshal(response.Result, &page); err != nil {
+		return nil, "", fmt.Errorf("invalid tools/list result: %w", err)
+	}
+
+	tools := make([]mcp.Tool, 0, len(page.Tools))
+	for _, raw := range page.Tools {
+		var tool mcp.Tool
+		var schemas struct {
+			InputSchema  json.RawMessage `json:"inputSchema"`
+			OutputSchema json.RawMessage `json:"outputSchema"`
+		}
+		if err = json.Unmarshal(raw, &tool); err != nil {
+			return nil, "", fmt.Errorf("invalid tool in tools/list result: %w", err)
+		}
+		_ = json.Unmarshal(raw, &schemas)
+		tool.InputSchema = mcp.ToolInputSchema{}
+		tool.OutputSchema = mcp.ToolOutputSchema{}
+		tool.RawInputSchema = rawSchema(schemas.InputSchema)
+		tool.RawOutputSchema = rawSchema(schemas.OutputSchema)
+		tools = append(tools, tool)
+	}
+	return tools, page.NextCursor, nil
+}
+
+// rawSchema returns a schema taken from JSON, or nil if it was absent or null
+func rawSchema(schema json.RawMessage) json.RawMessage {
+	if len(schema) == 0 || bytes.Equal(schema, []byte("null")) {
+		return nil
+	}
+	return schema
+}
+
+// listAll fetches every page of a list, following the cursor until the upstream returns an
+// empty one. A cursor the upstream already returned is an error, so a misbehaving upstream
+// cannot keep the gateway paging forever.
+func listAll[T any](ctx context.Context, fetch func(ctx context.Context, cursor mcp.Cursor) ([]T, mcp.Cursor, error)) ([]T, error) {
+	var all []T
+	var cursor mcp.Cursor
+	seen := make(map[mcp.Cursor]bool)
+	for {
+		page, next, err := fetch(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if next == "" {
+			return all, nil
+		}
+		if seen[next] {
+			return nil, fmt.Errorf("upstream repeated cursor '%s'", next)
+		}
+		seen[next] = true
+		cursor = next
+	}
+}
+
+// disconnect withdraws the upstream's definitions after its connection ended
+func (u *upstream) disconnect(reason error) {
+	u.mu.Lock()
+	wasConnected := u.client != nil
+	u.client = nil
+	u.connCtx = nil
+	u.tools = nil
+	u.resources = nil
+	u.templates = nil
+	u.prompts = nil
+	u.lastErr = reason
+	u.mu.Unlock()
+
+	if wasConnected {
+		u.gateway.notify()
+	}
+}
+
+// setError records why the last connection attempt failed
+func (u *upstream) setError(err error) {
+	u.mu.Lock()
+	u.lastErr = err
+	u.mu.Unlock()
+}
+
+// setState records a supervision state change
+func (u *upstream) setState(state State) {
+	u.mu.Lock()
+	u.state = state
+	u.since = time.Now()
+	u.mu.Unlock()
+}
+
+// stopProcess stops the process of a stdio upstream, if one is running
+func (u *upstream) stopProcess() {
+	u.mu.Lock()
+	p := u.proc
+	u.proc = nil
+	u.mu.Unlock()
+	if p == nil {
+		return
+	}
+
+	if err := p.stop(u.gateway.stopTimeout); err != nil {
+		u.gateway.logger.Warningf("Gateway: upstream '%s': %v", u.config.Name, err)
+	} else if p.err != nil {
+		u.gateway.logger.Infof("Gateway: upstream '%s' (pid %d) exited: %v", u.config.Name, p.pid(), p.err)
+	} else {
+		u.gateway.logger.Infof("Gateway: upstream '%s' (pid %d) exited", u.config.Name, p.pid())
+	}
+}
+
+// request returns the connected client and a context for one request to it, which is
+// bounded by the request timeout and cancelled if the connection ends
+func (u *upstream) request(ctx context.Context) (*client.Client, context.Context, context.CancelFunc, error) {
+	u.mu.RLock()
+	c, connCtx := u.client, u.connCtx
+	u.mu.RUnlock()
+	if c == nil {
+		return nil, nil, nil, fmt.Errorf("upstream '%s' is not connected", u.config.Name)
+	}
+
+	ctx, cancelCause := context.WithCancelCause(ctx)
+	ctx, cancel := context.WithTimeout(ctx, u.gateway.requestTimeout)
+	stop := context.AfterFunc(connCtx, func() {
+		cancelCause(fmt.Errorf("connection closed"))
+	})
+	return c, ctx, func() {
+		stop()
+		cancel()
+		cancelCause(nil)
+	}, nil
+}
+
+// requestError returns why a request failed, reporting a closed connection rather than the
+// cancellation it caused
+func requestError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+	return err
+}
+
+// status returns the connection state of the upstream
+func (u *upstream) status() UpstreamStatus {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	status := UpstreamStatus{
+		Name:      u.config.Name,
+		State:     u.state,
+		Since:     u.since,
+		Restarts:  u.restarts,
+		Connected: u.client != nil,
+		Tools:     len(u.tools),
+		Resources: len(u.resources) + len(u.templates),
+		Prompts:   len(u.prompts),
+	}
+	if u.proc != nil {
+		status.PID = u.proc.pid()
+	}
+	if u.lastErr != nil {
+		status.LastError = u.lastErr.Error()
+	}
+	return status
+}
@@ -0,0 +1,62 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package httptool
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Auth adds credentials to a request before it is sent to the API
+type Auth func(req *http.Request) error
+
+// BearerAuth sends a static token in the Authorization header
+func BearerAuth(token string) Auth {
+	return func(req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+}
+
+// TokenAuth sends a token obtained for each request in the Authorization header, for
+// tokens that expire or depend on the caller
+func TokenAuth(token func(ctx context.Context) (string, error)) Auth {
+	return func(req *http.Request) error {
+		value, err := token(req.Context())
+		if err != nil {
+			return fmt.Errorf("unable to obtain token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+value)
+		return nil
+	}
+}
+
+// APIKeyAuth sends an API key in the named header, e.g. "X-API-Key"
+func APIKeyAuth(header, key string) Auth {
+	return func(req *http.Request) error {
+		req.Header.Set(header, key)
+		return nil
+	}
+}
+
+// APIKeyQueryAuth sends an API key as the named query parameter
+func APIKeyQueryAuth(name, key string) Auth {
+	return func(req *http.Request) error {
+		query := req.URL.Query()
+		query.Set(name, key)
+		req.URL.RawQuery = query.Encode()
+		return nil
+	}
+}
+
+// BasicAuth sends a username and password with HTTP basic authentication
+func BasicAuth(username, password string) Auth {
+	return func(req *http.Request) error {
+		req.SetBasicAuth(username, password)
+		return nil
+	}
+}
@@ -0,0 +1,248 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+// Package httptool holds the HTTP handling shared by the tool providers that call APIs: the
+// openapi and rest packages. It authenticates and retries requests, limits response sizes and
+// turns responses into tool results.
+package httptool
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// maxRetryAfter caps the delay requested by a Retry-After header
+const maxRetryAfter = 30 * time.Second
+
+// Client sends the requests of a tool provider
+type Client struct {
+	HTTPClient      *http.Client
+	Auth            Auth          // Adds credentials to each attempt; may be nil
+	Retries         int           // Retries of idempotent requests
+	RetryWait       time.Duration // Initial delay between retries, doubled after each attempt
+	MaxResponseSize int64         // Longer response bodies are truncated
+	Logger          mcptypes.Logger
+	Name            string // Prefix of log messages, e.g. "REST"
+}
+
+// Response is a response whose body has been read, up to the maximum response size
+type Response struct {
+	Status     string
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	Truncated  bool // The body was longer than the maximum response size
+
+	limit int64
+}
+
+// Do sends a request, retrying idempotent requests that fail with a network error or a 429,
+// 502, 503 or 504 status with exponential backoff, honouring Retry-After. A request whose
+// body cannot be replayed (see http.Request.GetBody) is sent once.
+func (c *Client) Do(req *http.Request) (*Response, error) {
+	retries := 0
+	if Idempotent(req.Method) && (req.Body == nil || req.GetBody != nil) {
+		retries = c.Retries
+	}
+	wait := c.RetryWait
+
+	for attempt := 0; ; attempt++ {
+		out, err := c.prepare(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+		// Logged without the credentials: auth may add an API key to the query string
+		c.Logger.Debugf("%s: %s %s", c.Name, req.Method, req.URL.Redacted())
+		resp, err := c.HTTPClient.Do(out)
+		if attempt >= retries || !retryable(resp, err) {
+			if err != nil {
+				return nil, err
+			}
+			return c.read(resp)
+		}
+
+		delay := wait
+		if resp != nil {
+			if after := retryAfter(resp); after > 0 {
+				delay = after
+			}
+			_ = resp.Body.Close()
+		}
+		c.Logger.Debugf("%s: %s %s failed, retrying in %s", c.Name, req.Method, req.URL.Redacted(), delay)
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+		wait *= 2
+	}
+}
+
+// prepare returns a copy of a request for one attempt, with a fresh body and credentials
+func (c *Client) prepare(req *http.Request, attempt int) (*http.Request, error) {
+	out := req.Clone(req.Context())
+	if attempt > 0 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("unable to replay request body: %w", err)
+		}
+		out.Body = body
+	}
+	if c.Auth != nil {
+		if err := c.Auth(out); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// read reads and closes the response body, truncating it at the maximum response size
+func (c *Client) read(resp *http.Response) (*Response, error) {
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, c.MaxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	truncated := int64(len(data)) > c.MaxResponseSize
+	if truncated {
+		data = data[:c.MaxResponseSize]
+	}
+	return &Response{
+		Status:     resp.Status,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       data,
+		Truncated:  truncated,
+		limit:      c.MaxResponseSize,
+	}, nil
+}
+
+// OK reports whether the response has a 2xx status
+func (r *Response) OK() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// ErrorResult returns the status and body as a tool error, so the model can see the API's
+// explanation of a failed request
+func (r *Response) ErrorResult() *mcptypes.ToolResult {
+	return ErrorResult(fmt.Sprintf("HTTP %s: %s", r.Status, bytes.TrimSpace(r.Body)))
+}
+
+// TextResult returns the body as text, noting any truncation, or the status if it is empty
+func (r *Response) TextResult() *mcptypes.ToolResult {
+	text := string(r.Body)
+	if r.Truncated {
+		text += fmt.Sprintf("\n[response truncated to %d bytes]", r.limit)
+	}
+	if text == "" {
+		text = "HTTP " + r.Status
+	}
+	return mcptypes.NewToolResult(mcptypes.NewTextContent(text))
+}
+
+// ErrorResult returns a tool result reporting an error to the model
+func ErrorResult(text string) *mcptypes.ToolResult {
+	result := mcptypes.NewToolResult(mcptypes.NewTextContent(text))
+	result.IsError = true
+	return result
+}
+
+// ResolveURL returns the URL of a path relative to the base URL, including any path the base
+// URL has. The query parameters are added to those of the base URL.
+func ResolveURL(baseURL, path string, query url.Values) (string, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL: %w", err)
+	}
+	values := base.Query()
+	base.RawQuery = ""
+	base.Fragment = ""
+
+	target, err := url.Parse(strings.TrimSuffix(base.String(), "/") + path)
+	if err != nil {
+		return "", fmt.Errorf("invalid request URL: %w", err)
+	}
+	for key, list := range query {
+		values[key] = list
+	}
+	if len(values) > 0 {
+		target.RawQuery = values.Encode()
+	}
+	return target.String(), nil
+}
+
+// MethodHints derives tool hints from the HTTP method: GET, HEAD and OPTIONS are read-only,
+// DELETE is destructive and PUT is idempotent
+func MethodHints(method string) *mcptypes.ToolHints {
+	hints := mcptypes.NewHints()
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		hints.ReadOnly(true)
+	case http.MethodDelete:
+		hints.Destructive(true)
+	case http.MethodPut:
+		hints.Idempotent(true)
+	}
+	return hints
+}
+
+// Idempotent reports whether a request with the method may safely be repeated
+func Idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// FormatValue formats an argument for a path, query string, header or form field. Whole
+// numbers are written without a fraction and objects and arrays as JSON.
+func FormatValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case map[string]any, []any:
+		data, _ := json.Marshal(v)
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// retryable reports whether a failed attempt is worth repeating
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter returns the delay requested by a Retry-After header in seconds, capped at maxRetryAfter
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return min(time.Duration(seconds)*time.Second, maxRetryAfter)
+}
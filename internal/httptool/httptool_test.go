@@ -0,0 +1,281 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package httptool
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// flakyAPI answers with the given statuses in turn, then 200, and records each request
+type flakyAPI struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []string // Method, Authorization header and body of each request
+}
+
+func (a *flakyAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	a.mu.Lock()
+	a.requests = append(a.requests, r.Method+" "+r.Header.Get("Authorization")+" "+string(body))
+	status := http.StatusOK
+	if len(a.requests) <= len(a.statuses) {
+		status = a.statuses[len(a.requests)-1]
+	}
+	a.mu.Unlock()
+
+	if status == http.StatusTooManyRequests {
+		w.Header().Set("Retry-After", "1")
+	}
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, http.StatusText(status))
+}
+
+func newTestClient(retries int) *Client {
+	attempt := 0
+	return &Client{
+		HTTPClient: http.DefaultClient,
+		Auth: func(req *http.Request) error {
+			attempt++
+			req.Header.Set("Authorization", "Bearer "+strings.Repeat("t", attempt))
+			return nil
+		},
+		Retries:         retries,
+		RetryWait:       time.Millisecond,
+		MaxResponseSize: 1 << 10,
+		Logger:          mcptypes.NoopLogger{},
+		Name:            "test",
+	}
+}
+
+func TestDoRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		body     io.Reader
+		retries  int
+		statuses []int
+		want     []string // Expected requests
+		status   int      // Expected final status
+	}{
+		{
+			name:     "idempotent request retried with fresh credentials",
+			method:   http.MethodPut,
+			body:     strings.NewReader("data"),
+			retries:  2,
+			statuses: []int{http.StatusBadGateway, http.StatusServiceUnavailable},
+			want:     []string{"PUT Bearer t data", "PUT Bearer tt data", "PUT Bearer ttt data"},
+			status:   http.StatusOK,
+		},
+		{
+			name:     "gives up after the retries",
+			method:   http.MethodGet,
+			retries:  1,
+			statuses: []int{http.StatusGatewayTimeout, http.StatusGatewayTimeout},
+			want:     []string{"GET Bearer t ", "GET Bearer tt "},
+			status:   http.StatusGatewayTimeout,
+		},
+		{
+			name:     "non-idempotent request not retried",
+			method:   http.MethodPost,
+			body:     strings.NewReader("data"),
+			retries:  2,
+			statuses: []int{http.StatusServiceUnavailable},
+			want:     []string{"POST Bearer t data"},
+			status:   http.StatusServiceUnavailable,
+		},
+		{
+			name:     "client error not retried",
+			method:   http.MethodGet,
+			retries:  2,
+			statuses: []int{http.StatusNotFound},
+			want:     []string{"GET Bearer t "},
+			status:   http.StatusNotFound,
+		},
+		{
+			name:     "body that cannot be replayed",
+			method:   http.MethodPut,
+			body:     io.MultiReader(strings.NewReader("data")),
+			retries:  2,
+			statuses: []int{http.StatusBadGateway},
+			want:     []string{"PUT Bearer t data"},
+			status:   http.StatusBadGateway,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &flakyAPI{statuses: tt.statuses}
+			server := httptest.NewServer(api)
+			defer server.Close()
+
+			req, err := http.NewRequestWithContext(context.Background(), tt.method, server.URL, tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := newTestClient(tt.retries).Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if strings.Join(api.requests, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("requests = %q, want %q", api.requests, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoHonoursRetryAfter(t *testing.T) {
+	api := &flakyAPI{statuses: []int{http.StatusTooManyRequests}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	start := time.Now()
+	if _, err := newTestClient(1).Do(req); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want the 1s requested by Retry-After", elapsed)
+	}
+}
+
+func TestDoStopsWhenCancelled(t *testing.T) {
+	api := &flakyAPI{statuses: []int{http.StatusTooManyRequests}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if _, err := newTestClient(5).Do(req); err == nil {
+		t.Fatal("request succeeded after its context ended")
+	}
+	if len(api.requests) != 1 {
+		t.Errorf("sent %d requests, want 1", len(api.requests))
+	}
+}
+
+// recordingLogger records debug messages
+type recordingLogger struct {
+	mcptypes.NoopLogger
+	messages []string
+}
+
+func (l *recordingLogger) Debugf(format string, args ...any) {
+	l.messages = append(l.messages, fmt.Sprintf(format, args...))
+}
+
+func TestDoDoesNotLogQueryAPIKey(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	c := newTestClient(1)
+	c.Auth = APIKeyQueryAuth("api_key", "s3cret")
+	c.Logger = logger
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"?page=2", nil)
+	if _, err := c.Do(req); err != nil {
+		t.Fatal(err)
+	}
+
+	if query != "api_key=s3cret&page=2" {
+		t.Errorf("query sent = %q, want the API key added", query)
+	}
+	if len(logger.messages) == 0 {
+		t.Fatal("request not logged")
+	}
+	for _, message := range logger.messages {
+		if strings.Contains(message, "s3cret") {
+			t.Errorf("API key logged: %s", message)
+		}
+	}
+}
+
+func TestResponseResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		status  int
+		isError bool
+		want    string
+	}{
+		{"text", "hello", http.StatusOK, false, "hello"},
+		{"empty", "", http.StatusNoContent, false, "HTTP 204 No Content"},
+		{"truncated", strings.Repeat("x", 12), http.StatusOK, false, "xxxxxxxxxx\n[response truncated to 10 bytes]"},
+		{"error", " not found \n", http.StatusNotFound, true, "HTTP 404 Not Found: not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			c := newTestClient(0)
+			c.MaxResponseSize = 10
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			resp, err := c.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result := resp.TextResult()
+			if !resp.OK() {
+				result = resp.ErrorResult()
+			}
+			if result.IsError != tt.isError || len(result.Content) != 1 || result.Content[0].Text != tt.want {
+				t.Errorf("result = %+v, want %q (error %t)", result, tt.want, tt.isError)
+			}
+		})
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{"text", "text"},
+		{float64(42), "42"},
+		{1.5, "1.5"},
+		{true, "true"},
+		{[]any{"a", 1.0}, `["a",1]`},
+		{map[string]any{"k": "v"}, `{"k":"v"}`},
+		{int64(7), "7"},
+	}
+	for _, tt := range tests {
+		if got := FormatValue(tt.value); got != tt.want {
+			t.Errorf("FormatValue(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestResolveURL(t *testing.T) {
+	got, err := ResolveURL("https://api.example.com/v1/?key=k", "/widgets/42", map[string][]string{"limit": {"5"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://api.example.com/v1/widgets/42?key=k&limit=5"; got != want {
+		t.Errorf("ResolveURL() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,123 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package jwt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// maxJWKSSize limits the size of a JWKS document
+const maxJWKSSize = 1 << 20
+
+// keySet caches the keys published at a JWKS URL. The keys are fetched again when the cache
+// expires, and early when a token names a key ID that is not in the cache so that rotated
+// keys are picked up without waiting for expiry. Those early fetches are rate limited so that
+// tokens with made-up key IDs cannot be used to flood the JWKS endpoint.
+type keySet struct {
+	url        string
+	httpClient *http.Client
+	logger     mcptypes.Logger
+	ttl        time.Duration // How long fetched keys are used before fetching again
+	minRefresh time.Duration // Minimum time between fetches caused by unknown key IDs
+
+	mu        sync.Mutex
+	keys      []*key
+	fetched   time.Time // Time of the last fetch
+	refreshed time.Time // Time of the last fetch caused by an unknown key ID
+}
+
+// lookup returns the cached keys for a key ID, or all keys if the ID is empty, fetching
+// the set first if it has expired or does not contain the ID
+func (s *keySet) lookup(ctx context.Context, id string) ([]*key, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fetched.IsZero() || time.Since(s.fetched) > s.ttl {
+		if err := s.refresh(ctx); err != nil && s.keys == nil {
+			return nil, err
+		}
+	} else if id != "" && s.find(id) == nil && time.Since(s.refreshed) > s.minRefresh {
+		s.refreshed = time.Now()
+		if err := s.refresh(ctx); err != nil {
+			s.logger.Warningf("JWT: %v", err)
+		}
+	}
+	return s.find(id), nil
+}
+
+// find returns the cached keys matching a key ID, or all keys if the ID is empty
+func (s *keySet) find(id string) []*key {
+	if id == "" {
+		return s.keys
+	}
+	var matches []*key
+	for _, k := range s.keys {
+		if k.id == id {
+			matches = append(matches, k)
+		}
+	}
+	return matches
+}
+
+// refresh fetches the key set. On failure the cached keys are kept, and the failure counts
+// as a fetch so that a broken endpoint is not hit on every request.
+func (s *keySet) refresh(ctx context.Context) error {
+	s.fetched = time.Now()
+	keys, err := s.fetch(ctx)
+	if err != nil {
+		if s.keys != nil {
+			s.logger.Warningf("JWT: keeping %d cached keys: %v", len(s.keys), err)
+		}
+		return err
+	}
+	s.keys = keys
+	s.logger.Debugf("JWT: loaded %d keys from %s", len(keys), s.url)
+	return nil
+}
+
+// fetch downloads and parses the key set. Keys that cannot be used are skipped.
+func (s *keySet) fetch(ctx context.Context) ([]*key, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWKS request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: %s", resp.Status)
+	}
+
+	var document struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err = json.NewDecoder(io.LimitReader(resp.Body, maxJWKSSize)).Decode(&document); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	keys := make([]*key, 0, len(document.Keys))
+	for _, k := range document.Keys {
+		parsed, err := parseJWK(k)
+		if err != nil {
+			s.logger.Debugf("JWT: skipping JWKS key: %v", err)
+			continue
+		}
+		keys = append(keys, parsed)
+	}
+	return keys, nil
+}
@@ -0,0 +1,351 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+// Package jwt validates JWT bearer tokens, such as OAuth 2.0 access tokens, against static
+// keys or the keys published at a JWKS URL, and checks the issuer, audience and validity
+// period. A Validator supplies the mcptypes.BearerTokenValidator for mcpserver.
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// ErrInvalidToken is wrapped by every error returned for a token that is rejected
+var ErrInvalidToken = errors.New("invalid token")
+
+// Option defines a function type for configuring the Validator
+type Option func(*Validator)
+
+// Validator validates JWTs. It is safe for concurrent use.
+type Validator struct {
+	logger     mcptypes.Logger
+	httpClient *http.Client
+	jwksURL    string
+	cacheTTL   time.Duration
+	keys       []*key
+	issuers    []string
+	audiences  []string
+	algorithms []string
+	skew       time.Duration
+	timeout    time.Duration
+
+	jwks *keySet
+}
+
+// WithLogger sets the logger
+func WithLogger(logger mcptypes.Logger) Option {
+	return func(v *Validator) {
+		v.logger = logger
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to fetch the JWKS
+func WithHTTPClient(client *http.Client) Option {
+	return func(v *Validator) {
+		v.httpClient = client
+	}
+}
+
+// WithJWKSURL sets the URL of the JSON Web Key Set used to verify signatures
+func WithJWKSURL(url string) Option {
+	return func(v *Validator) {
+		v.jwksURL = url
+	}
+}
+
+// WithCacheTTL sets how long keys fetched from the JWKS URL are used before they are
+// fetched again (default: 1 hour). Unknown key IDs trigger an early fetch regardless.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(v *Validator) {
+		v.cacheTTL = ttl
+	}
+}
+
+// WithPublicKey adds a static RSA, ECDSA or Ed25519 public key. If id is not empty, only
+// tokens with a matching kid header use the key.
+func WithPublicKey(id string, public crypto.PublicKey) Option {
+	return func(v *Validator) {
+		v.keys = append(v.keys, &key{id: id, public: public})
+	}
+}
+
+// WithHMACSecret adds a shared secret for the HS256, HS384 and HS512 algorithms
+func WithHMACSecret(secret []byte) Option {
+	return func(v *Validator) {
+		v.keys = append(v.keys, &key{secret: secret})
+	}
+}
+
+// WithIssuer requires the iss claim to be one of the given issuers
+func WithIssuer(issuers ...string) Option {
+	return func(v *Validator) {
+		v.issuers = append(v.issuers, issuers...)
+	}
+}
+
+// WithAudience requires the aud claim to contain at least one of the given audiences,
+// normally the URL of this server
+func WithAudience(audiences ...string) Option {
+	return func(v *Validator) {
+		v.audiences = append(v.audiences, audiences...)
+	}
+}
+
+// WithAlgorithms restricts the accepted signing algorithms (default: all supported
+// algorithms that suit the configured keys)
+func WithAlgorithms(algorithms ...string) Option {
+	return func(v *Validator) {
+		v.algorithms = algorithms
+	}
+}
+
+// WithClockSkew sets the tolerance applied to the exp, nbf and iat claims (default: 1 minute)
+func WithClockSkew(skew time.Duration) Option {
+	return func(v *Validator) {
+		v.skew = skew
+	}
+}
+
+// WithTimeout bounds each validation made through BearerTokenValidator, including any
+// JWKS fetch (default: 10 seconds)
+func WithTimeout(timeout time.Duration) Option {
+	return func(v *Validator) {
+		v.timeout = timeout
+	}
+}
+
+// New creates a validator. At least one key source (WithJWKSURL, WithPublicKey or
+// WithHMACSecret) is required.
+func New(options ...Option) (*Validator, error) {
+	v := &Validator{
+		cacheTTL: time.Hour,
+		skew:     time.Minute,
+		timeout:  10 * time.Second,
+	}
+	for _, opt := range options {
+		opt(v)
+	}
+	if v.logger == nil {
+		v.logger = mcptypes.NoopLogger{}
+	}
+	if v.httpClient == nil {
+		v.httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	if v.jwksURL == "" && len(v.keys) == 0 {
+		return nil, errors.New("no keys configured: use WithJWKSURL, WithPublicKey or WithHMACSecret")
+	}
+	for _, k := range v.keys {
+		switch public := k.public.(type) {
+		case nil:
+			if k.secret == nil {
+				return nil, errors.New("invalid key: no public key or secret")
+			}
+		case *rsa.PublicKey:
+			if public.N.BitLen() < 2048 {
+				return nil, errors.New("invalid key: RSA keys must be at least 2048 bits")
+			}
+		case *ecdsa.PublicKey, ed25519.PublicKey:
+		default:
+			return nil, fmt.Errorf("unsupported public key type %T", k.public)
+		}
+	}
+	for _, algorithm := range v.algorithms {
+		if _, ok := algorithms[algorithm]; !ok {
+			return nil, fmt.Errorf("unsupported algorithm '%s'", algorithm)
+		}
+	}
+
+	if v.jwksURL != "" {
+		v.jwks = &keySet{
+			url:        v.jwksURL,
+			httpClient: v.httpClient,
+			logger:     v.logger,
+			ttl:        v.cacheTTL,
+			minRefresh: min(v.cacheTTL, 30*time.Second),
+		}
+	}
+	return v, nil
+}
+
+// BearerTokenValidator returns a validator for mcpserver.WithBearerTokenAuth. The
+// context data is the token's claims.
+func (v *Validator) BearerTokenValidator() mcptypes.BearerTokenValidator {
+	return func(token string) (map[string]any, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), v.timeout)
+		defer cancel()
+		return v.Validate(ctx, token)
+	}
+}
+
+// header is the JOSE header of a token
+type header struct {
+	Alg  string   `json:"alg"`
+	Kid  string   `json:"kid"`
+	Crit []string `json:"crit"`
+}
+
+// Validate verifies the signature of a compact JWS token and checks its claims, returning
+// the claims if the token is valid. The exp claim is required; nbf and iat are checked if
+// present.
+func (v *Validator) Validate(ctx context.Context, token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed JWT", ErrInvalidToken)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("%w: malformed header: %v", ErrInvalidToken, err)
+	}
+	if _, ok := algorithms[h.Alg]; !ok {
+		return nil, fmt.Errorf("%w: unsupported algorithm '%s'", ErrInvalidToken, h.Alg)
+	}
+	if len(v.algorithms) > 0 && !slices.Contains(v.algorithms, h.Alg) {
+		return nil, fmt.Errorf("%w: algorithm '%s' is not accepted", ErrInvalidToken, h.Alg)
+	}
+	if len(h.Crit) > 0 {
+		return nil, fmt.Errorf("%w: unsupported critical header %v", ErrInvalidToken, h.Crit)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+	if err = v.verify(ctx, h, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	var claims map[string]any
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims: %v", ErrInvalidToken, err)
+	}
+	if err = v.checkClaims(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// verify checks the signature with each key that suits the header
+func (v *Validator) verify(ctx context.Context, h header, input, signature []byte) error {
+	candidates := make([]*key, 0, len(v.keys))
+	for _, k := range v.keys {
+		if k.id == "" || h.Kid == "" || k.id == h.Kid {
+			candidates = append(candidates, k)
+		}
+	}
+	if v.jwks != nil && !strings.HasPrefix(h.Alg, "HS") {
+		keys, err := v.jwks.lookup(ctx, h.Kid)
+		if err != nil {
+			return fmt.Errorf("unable to load signing keys: %w", err)
+		}
+		candidates = append(candidates, keys...)
+	}
+
+	found := false
+	for _, k := range candidates {
+		if !k.suits(h.Alg) {
+			continue
+		}
+		found = true
+		if k.verify(h.Alg, input, signature) == nil {
+			return nil
+		}
+	}
+	if !found {
+		if h.Kid != "" {
+			return fmt.Errorf("%w: no %s key with ID '%s'", ErrInvalidToken, h.Alg, h.Kid)
+		}
+		return fmt.Errorf("%w: no %s key", ErrInvalidToken, h.Alg)
+	}
+	return fmt.Errorf("%w: %v", ErrInvalidToken, errSignature)
+}
+
+// checkClaims checks the registered claims against the configuration and the current time
+func (v *Validator) checkClaims(claims map[string]any) error {
+	now := time.Now()
+
+	exp, ok, err := numericDate(claims, "exp")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: missing exp claim", ErrInvalidToken)
+	}
+	if now.After(exp.Add(v.skew)) {
+		return fmt.Errorf("%w: token expired at %s", ErrInvalidToken, exp.Format(time.RFC3339))
+	}
+
+	for _, name := range []string{"nbf", "iat"} {
+		t, ok, err := numericDate(claims, name)
+		if err != nil {
+			return err
+		}
+		if ok && t.After(now.Add(v.skew)) {
+			return fmt.Errorf("%w: %s claim is in the future", ErrInvalidToken, name)
+		}
+	}
+
+	if len(v.issuers) > 0 {
+		issuer, _ := claims["iss"].(string)
+		if !slices.Contains(v.issuers, issuer) {
+			return fmt.Errorf("%w: unexpected issuer '%s'", ErrInvalidToken, issuer)
+		}
+	}
+
+	if len(v.audiences) > 0 {
+		var audiences []string
+		switch aud := claims["aud"].(type) {
+		case string:
+			audiences = []string{aud}
+		case []any:
+			for _, a := range aud {
+				if s, ok := a.(string); ok {
+					audiences = append(audiences, s)
+				}
+			}
+		}
+		if !slices.ContainsFunc(audiences, func(a string) bool { return slices.Contains(v.audiences, a) }) {
+			return fmt.Errorf("%w: token is not intended for this audience", ErrInvalidToken)
+		}
+	}
+	return nil
+}
+
+// numericDate returns a NumericDate claim (seconds since the epoch) and whether it is present
+func numericDate(claims map[string]any, name string) (time.Time, bool, error) {
+	value, ok := claims[name]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	seconds, ok := value.(float64)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("%w: %s claim is not a number", ErrInvalidToken, name)
+	}
+	return time.Unix(int64(seconds), 0), true, nil
+}
+
+// decodeSegment decodes a base64url-encoded JSON segment of a token
+func decodeSegment(segment string, value any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, value)
+}
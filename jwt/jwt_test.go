@@ -0,0 +1,323 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package jwt_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PivotLLM/MCPLaunchPad/jwt"
+	"github.com/PivotLLM/MCPLaunchPad/mcptest"
+)
+
+const audience = "https://mcp.example.com"
+
+// encode returns the base64url encoding of a JSON value
+func encode(t *testing.T, value any) string {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// craft returns a token with the header and claims, signed by sign over the signing input
+func craft(t *testing.T, header, claims map[string]any, sign func(input []byte) []byte) string {
+	t.Helper()
+	input := encode(t, header) + "." + encode(t, claims)
+	return input + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(input)))
+}
+
+// hs256 signs with HMAC-SHA256 using the secret
+func hs256(secret []byte) func([]byte) []byte {
+	return func(input []byte) []byte {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(input)
+		return mac.Sum(nil)
+	}
+}
+
+// rs256 signs with RSASSA-PKCS1-v1_5 using SHA-256
+func rs256(t *testing.T, private *rsa.PrivateKey) func([]byte) []byte {
+	return func(input []byte) []byte {
+		digest := sha256.Sum256(input)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, private, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signature
+	}
+}
+
+// validClaims returns claims that pass the checks of newValidator
+func validClaims(issuer *mcptest.Issuer) map[string]any {
+	return map[string]any{
+		"iss": issuer.URL(),
+		"aud": audience,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+// newValidator creates a validator for the issuer's JWKS, issuer and the test audience
+func newValidator(t *testing.T, issuer *mcptest.Issuer, options ...jwt.Option) *jwt.Validator {
+	t.Helper()
+	v, err := jwt.New(append([]jwt.Option{
+		jwt.WithJWKSURL(issuer.JWKSURL()),
+		jwt.WithIssuer(issuer.URL()),
+		jwt.WithAudience(audience),
+	}, options...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+// publishedRSAKey returns the RSA public key the issuer publishes
+func publishedRSAKey(t *testing.T, issuer *mcptest.Issuer) *rsa.PublicKey {
+	t.Helper()
+	resp, err := http.Get(issuer.JWKSURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	var document struct {
+		Keys []struct{ Kty, N string }
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range document.Keys {
+		if k.Kty == "RSA" {
+			n, _ := base64.RawURLEncoding.DecodeString(k.N)
+			return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537}
+		}
+	}
+	t.Fatal("issuer publishes no RSA key")
+	return nil
+}
+
+// pemKey returns the PEM encoding of a public key, the usual secret in algorithm confusion attacks
+func pemKey(t *testing.T, public *rsa.PublicKey) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestValidateAlgorithms(t *testing.T) {
+	issuer := mcptest.NewIssuer(t)
+	public := publishedRSAKey(t, issuer)
+
+	tests := []struct {
+		name    string
+		options []jwt.Option
+		token   func() string
+		valid   bool
+	}{
+		{
+			name:  "RS256",
+			token: func() string { return issuer.Token("RS256", validClaims(issuer)) },
+			valid: true,
+		},
+		{
+			name:  "ES256",
+			token: func() string { return issuer.Token("ES256", validClaims(issuer)) },
+			valid: true,
+		},
+		{
+			name:  "EdDSA",
+			token: func() string { return issuer.Token("EdDSA", validClaims(issuer)) },
+			valid: true,
+		},
+		{
+			name: "alg none",
+			token: func() string {
+				return craft(t, map[string]any{"alg": "none"}, validClaims(issuer), func([]byte) []byte { return nil })
+			},
+		},
+		{
+			name: "HS256 signed with the published RSA key",
+			token: func() string {
+				return craft(t, map[string]any{"alg": "HS256", "kid": "RS256-1"}, validClaims(issuer), hs256(pemKey(t, public)))
+			},
+		},
+		{
+			name:    "HS256 signed with a static RSA key",
+			options: []jwt.Option{jwt.WithPublicKey("", public)},
+			token: func() string {
+				return craft(t, map[string]any{"alg": "HS256"}, validClaims(issuer), hs256(pemKey(t, public)))
+			},
+		},
+		{
+			name:    "HS256 signed with the raw RSA modulus",
+			options: []jwt.Option{jwt.WithPublicKey("", public)},
+			token: func() string {
+				return craft(t, map[string]any{"alg": "HS256"}, validClaims(issuer), hs256(public.N.Bytes()))
+			},
+		},
+		{
+			name:    "algorithm outside the allow-list",
+			options: []jwt.Option{jwt.WithAlgorithms(jwt.ES256)},
+			token:   func() string { return issuer.Token("RS256", validClaims(issuer)) },
+		},
+		{
+			name:    "algorithm in the allow-list",
+			options: []jwt.Option{jwt.WithAlgorithms(jwt.ES256, jwt.EdDSA)},
+			token:   func() string { return issuer.Token("EdDSA", validClaims(issuer)) },
+			valid:   true,
+		},
+		{
+			name:    "HS256 with a configured secret",
+			options: []jwt.Option{jwt.WithHMACSecret(issuer.Secret())},
+			token:   func() string { return issuer.Token("HS256", validClaims(issuer)) },
+			valid:   true,
+		},
+		{
+			name:  "HS256 without a configured secret",
+			token: func() string { return issuer.Token("HS256", validClaims(issuer)) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newValidator(t, issuer, tt.options...)
+			_, err := v.Validate(context.Background(), tt.token())
+			switch {
+			case tt.valid && err != nil:
+				t.Errorf("valid token rejected: %v", err)
+			case !tt.valid && err == nil:
+				t.Error("invalid token accepted")
+			case !tt.valid && !errors.Is(err, jwt.ErrInvalidToken):
+				t.Errorf("error %v does not wrap ErrInvalidToken", err)
+			}
+		})
+	}
+}
+
+func TestValidateClaims(t *testing.T) {
+	issuer := mcptest.NewIssuer(t)
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		claims map[string]any // Changes to the valid claims; nil removes a claim
+		valid  bool
+	}{
+		{"valid", nil, true},
+		{"expired within the skew", map[string]any{"exp": now.Add(-30 * time.Second).Unix()}, true},
+		{"expired beyond the skew", map[string]any{"exp": now.Add(-2 * time.Minute).Unix()}, false},
+		{"missing exp", map[string]any{"exp": nil}, false},
+		{"exp not a number", map[string]any{"exp": "tomorrow"}, false},
+		{"not yet valid within the skew", map[string]any{"nbf": now.Add(30 * time.Second).Unix()}, true},
+		{"not yet valid beyond the skew", map[string]any{"nbf": now.Add(2 * time.Minute).Unix()}, false},
+		{"issued in the future", map[string]any{"iat": now.Add(2 * time.Minute).Unix()}, false},
+		{"wrong issuer", map[string]any{"iss": "https://evil.example.com"}, false},
+		{"missing issuer", map[string]any{"iss": nil}, false},
+		{"wrong audience", map[string]any{"aud": "https://other.example.com"}, false},
+		{"audience in a list", map[string]any{"aud": []string{"https://other.example.com", audience}}, true},
+		{"missing audience", map[string]any{"aud": nil}, false},
+	}
+
+	v := newValidator(t, issuer, jwt.WithClockSkew(time.Minute))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims(issuer)
+			for name, value := range tt.claims {
+				claims[name] = value
+			}
+			_, err := v.Validate(context.Background(), issuer.Token("RS256", claims))
+			if tt.valid && err != nil {
+				t.Errorf("valid token rejected: %v", err)
+			} else if !tt.valid && err == nil {
+				t.Error("invalid token accepted")
+			}
+		})
+	}
+}
+
+func TestUnknownKeyRefreshesJWKS(t *testing.T) {
+	issuer := mcptest.NewIssuer(t)
+	v := newValidator(t, issuer)
+	ctx := context.Background()
+
+	if _, err := v.Validate(ctx, issuer.Token("RS256", validClaims(issuer))); err != nil {
+		t.Fatal(err)
+	}
+	if fetches := issuer.Fetches(); fetches != 1 {
+		t.Fatalf("key set fetched %d times, want 1", fetches)
+	}
+
+	// A token signed with a rotated key names an unknown key ID, which forces a fetch
+	issuer.Rotate()
+	if _, err := v.Validate(ctx, issuer.Token("RS256", validClaims(issuer))); err != nil {
+		t.Fatalf("token signed with a rotated key rejected: %v", err)
+	}
+	if fetches := issuer.Fetches(); fetches != 2 {
+		t.Fatalf("key set fetched %d times, want 2", fetches)
+	}
+
+	// Further unknown key IDs within the refresh interval are rejected without a fetch
+	for _, kid := range []string{"made-up-1", "made-up-2", "made-up-3"} {
+		token := craft(t, map[string]any{"alg": "RS256", "kid": kid}, validClaims(issuer), func([]byte) []byte { return []byte("sig") })
+		if _, err := v.Validate(ctx, token); err == nil {
+			t.Fatalf("token with unknown key ID %s accepted", kid)
+		}
+	}
+	if fetches := issuer.Fetches(); fetches != 2 {
+		t.Errorf("key set fetched %d times after unknown key IDs, want 2", fetches)
+	}
+}
+
+func TestRejectSmallRSAKeys(t *testing.T) {
+	small, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("static key", func(t *testing.T) {
+		if _, err := jwt.New(jwt.WithPublicKey("", &small.PublicKey)); err == nil {
+			t.Error("accepted a 1024-bit RSA key")
+		}
+	})
+
+	t.Run("JWKS key", func(t *testing.T) {
+		jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]any{{
+				"kty": "RSA", "kid": "small", "alg": "RS256",
+				"n": base64.RawURLEncoding.EncodeToString(small.N.Bytes()),
+				"e": base64.RawURLEncoding.EncodeToString([]byte{1, 0, 1}),
+			}}})
+		}))
+		defer jwks.Close()
+
+		v, err := jwt.New(jwt.WithJWKSURL(jwks.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+		claims := map[string]any{"exp": time.Now().Add(time.Hour).Unix()}
+		token := craft(t, map[string]any{"alg": "RS256", "kid": "small"}, claims, rs256(t, small))
+		if _, err = v.Validate(context.Background(), token); err == nil {
+			t.Error("accepted a token signed with a 1024-bit RSA key")
+		}
+	})
+}
@@ -0,0 +1,213 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256" // Registers the SHA-256 hash
+	_ "crypto/sha512" // Registers the SHA-384 and SHA-512 hashes
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Supported signing algorithms
+const (
+	RS256 = "RS256"
+	RS384 = "RS384"
+	RS512 = "RS512"
+	PS256 = "PS256"
+	PS384 = "PS384"
+	PS512 = "PS512"
+	ES256 = "ES256"
+	ES384 = "ES384"
+	ES512 = "ES512"
+	EdDSA = "EdDSA"
+	HS256 = "HS256"
+	HS384 = "HS384"
+	HS512 = "HS512"
+)
+
+// errSignature is returned when no key verifies the signature
+var errSignature = errors.New("invalid signature")
+
+// algorithms maps each supported algorithm to its hash function
+var algorithms = map[string]crypto.Hash{
+	RS256: crypto.SHA256, RS384: crypto.SHA384, RS512: crypto.SHA512,
+	PS256: crypto.SHA256, PS384: crypto.SHA384, PS512: crypto.SHA512,
+	ES256: crypto.SHA256, ES384: crypto.SHA384, ES512: crypto.SHA512,
+	EdDSA: 0,
+	HS256: crypto.SHA256, HS384: crypto.SHA384, HS512: crypto.SHA512,
+}
+
+// key is a verification key with the optional metadata of its JWK
+type key struct {
+	id        string
+	algorithm string // Empty if the key does not restrict the algorithm
+	public    crypto.PublicKey
+	secret    []byte
+}
+
+// jwk is a JSON Web Key (RFC 7517) as published in a JWKS document
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWK converts a JWK to a verification key. Symmetric keys are rejected because a
+// shared secret must never be fetched from a URL.
+func parseJWK(k jwk) (*key, error) {
+	if k.Use != "" && k.Use != "sig" {
+		return nil, fmt.Errorf("key '%s' is not a signing key", k.Kid)
+	}
+
+	result := &key{id: k.Kid, algorithm: k.Alg}
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("key '%s': invalid modulus: %w", k.Kid, err)
+		}
+		e, err := decodeInt(k.E)
+		if err != nil || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("key '%s': invalid exponent", k.Kid)
+		}
+		if n.BitLen() < 2048 {
+			return nil, fmt.Errorf("key '%s': RSA keys must be at least 2048 bits", k.Kid)
+		}
+		result.public = &rsa.PublicKey{N: n, E: int(e.Int64())}
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("key '%s': unsupported curve '%s'", k.Kid, k.Crv)
+		}
+		x, errX := decodeInt(k.X)
+		y, errY := decodeInt(k.Y)
+		if errX != nil || errY != nil || !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("key '%s': invalid EC point", k.Kid)
+		}
+		result.public = &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("key '%s': unsupported curve '%s'", k.Kid, k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("key '%s': invalid Ed25519 key", k.Kid)
+		}
+		result.public = ed25519.PublicKey(x)
+
+	default:
+		return nil, fmt.Errorf("key '%s': unsupported key type '%s'", k.Kid, k.Kty)
+	}
+	return result, nil
+}
+
+// decodeInt decodes a base64url-encoded big-endian integer
+func decodeInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// suits reports whether the key can verify signatures made with the algorithm. The key type
+// must match the algorithm so that, for example, a public key is never used as an HMAC secret.
+func (k *key) suits(algorithm string) bool {
+	if k.algorithm != "" && k.algorithm != algorithm {
+		return false
+	}
+	switch algorithm[:2] {
+	case "RS", "PS":
+		_, ok := k.public.(*rsa.PublicKey)
+		return ok
+	case "ES":
+		public, ok := k.public.(*ecdsa.PublicKey)
+		return ok && public.Curve.Params().BitSize == curveBits(algorithm)
+	case "Ed":
+		_, ok := k.public.(ed25519.PublicKey)
+		return ok
+	case "HS":
+		return k.secret != nil
+	}
+	return false
+}
+
+// curveBits returns the size of the curve used by an ECDSA algorithm
+func curveBits(algorithm string) int {
+	switch algorithm {
+	case ES256:
+		return 256
+	case ES384:
+		return 384
+	default:
+		return 521
+	}
+}
+
+// verify checks a signature over the signing input (header.payload)
+func (k *key) verify(algorithm string, input, signature []byte) error {
+	hash := algorithms[algorithm]
+	var digest []byte
+	if hash != 0 {
+		h := hash.New()
+		h.Write(input)
+		digest = h.Sum(nil)
+	}
+
+	var ok bool
+	switch algorithm[:2] {
+	case "RS":
+		ok = rsa.VerifyPKCS1v15(k.public.(*rsa.PublicKey), hash, digest, signature) == nil
+	case "PS":
+		ok = rsa.VerifyPSS(k.public.(*rsa.PublicKey), hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}) == nil
+	case "ES":
+		// JWS uses the fixed-size concatenation of r and s rather than ASN.1
+		public := k.public.(*ecdsa.PublicKey)
+		size := (public.Curve.Params().BitSize + 7) / 8
+		if len(signature) == 2*size {
+			r := new(big.Int).SetBytes(signature[:size])
+			s := new(big.Int).SetBytes(signature[size:])
+			ok = ecdsa.Verify(public, digest, r, s)
+		}
+	case "Ed":
+		ok = ed25519.Verify(k.public.(ed25519.PublicKey), input, signature)
+	case "HS":
+		mac := hmac.New(hash.New, k.secret)
+		mac.Write(input)
+		ok = hmac.Equal(mac.Sum(nil), signature)
+	}
+	if !ok {
+		return errSignature
+	}
+	return nil
+}
@@ -16,7 +16,7 @@ import (
 
 	"github.com/PivotLLM/MCPLaunchPad/example1"
 	"github.com/PivotLLM/MCPLaunchPad/example2"
-	"github.com/PivotLLM/MCPLaunchPad/global"
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
 	"github.com/PivotLLM/MCPLaunchPad/mcpserver"
 	"github.com/PivotLLM/MCPLaunchPad/mlogger"
 )
@@ -126,7 +126,7 @@ func main() {
 	)
 
 	// Create a slice (list) of tool providers
-	providers := []global.ToolProvider{
+	providers := []mcptypes.ToolProvider{
 		tp1,
 		tp2,
 	}
@@ -145,8 +145,8 @@ func main() {
 		mcpserver.WithToolProviders(providers),
 
 		// Example1 also provides resources and prompts
-		mcpserver.WithResourceProviders([]global.ResourceProvider{tp1}),
-		mcpserver.WithPromptProviders([]global.PromptProvider{tp1}),
+		mcpserver.WithResourceProviders([]mcptypes.ResourceProvider{tp1}),
+		mcpserver.WithPromptProviders([]mcptypes.PromptProvider{tp1}),
 	)
 	if err != nil {
 		logger.Fatalf("Unable to create MCP server: %v", err)
@@ -0,0 +1,183 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// Authentication failures. The HTTP transports map them to Bearer error codes (RFC 6750).
+var (
+	errNoCredentials        = errors.New("authentication required")
+	errMalformedCredentials = errors.New("invalid authorization format - expected Bearer token")
+	errInvalidToken         = errors.New("invalid token")
+	errInsufficientScope    = errors.New("insufficient scope")
+)
+
+// authContextKey is the context key under which the caller's AuthInfo is stored. Being an
+// unexported type, it cannot collide with keys set by other packages.
+type authContextKey struct{}
+
+// credentialsContextKey is the context key under which the credentials a transport received
+// are stored
+type credentialsContextKey struct{}
+
+// credentials are a bearer token received by a transport, together with the validator and
+// required scopes of the listener that received it
+type credentials struct {
+	token     string
+	validator mcptypes.BearerTokenValidator
+	scopes    []string // Scopes the token must grant
+	request   bool     // Authenticated for the HTTP request being served, so not validated again
+}
+
+// authenticate validates the token and checks its expiry and the required scopes. Every transport
+// authenticates callers through this method: the HTTP transports on each request, the stdio
+// transport when the session starts, and the tool middleware again on each tool call of a
+// long-lived session.
+func (c *credentials) authenticate() (*mcptypes.AuthInfo, error) {
+	if c.token == "" {
+		return nil, errNoCredentials
+	}
+	data, err := c.validator(c.token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidToken, err)
+	}
+	info := bearerAuthInfo(data)
+	if !info.ExpiresAt.IsZero() && time.Now().After(info.ExpiresAt) {
+		return nil, fmt.Errorf("%w: token expired", errInvalidToken)
+	}
+	if missing := missingScopes(info.Scopes, c.scopes); len(missing) > 0 {
+		return nil, fmt.Errorf("%w: missing %s", errInsufficientScope, strings.Join(missing, " "))
+	}
+	return info, nil
+}
+
+// parseAuthorization returns the token in an Authorization value of the form "Bearer <token>".
+// The scheme is case-insensitive.
+func parseAuthorization(value string) (string, error) {
+	if value == "" {
+		return "", errNoCredentials
+	}
+	const prefix = "bearer "
+	if len(value) < len(prefix) || !strings.EqualFold(value[:len(prefix)], prefix) {
+		return "", errMalformedCredentials
+	}
+	token := strings.TrimSpace(value[len(prefix):])
+	if token == "" {
+		return "", errMalformedCredentials
+	}
+	return token, nil
+}
+
+// withCredentials stores the credentials a transport received, and the auth info they
+// authenticated, in the context
+func withCredentials(ctx context.Context, creds *credentials, info *mcptypes.AuthInfo) context.Context {
+	ctx = context.WithValue(ctx, credentialsContextKey{}, creds)
+	return ContextWithAuth(ctx, info)
+}
+
+// withConnectionCredentials prepares the context of a long-lived connection opened by an HTTP
+// request, such as a WebSocket, so that the tool middleware validates the request's credentials
+// again on each call
+func withConnectionCredentials(ctx context.Context) context.Context {
+	creds, ok := ctx.Value(credentialsContextKey{}).(*credentials)
+	if !ok || !creds.request {
+		return ctx
+	}
+	connection := *creds
+	connection.request = false
+	return context.WithValue(ctx, credentialsContextKey{}, &connection)
+}
+
+// credentialsFromContext returns the credentials the transport received for the request, from
+// the request itself or from the session it belongs to
+func credentialsFromContext(ctx context.Context) (*credentials, bool) {
+	if creds, ok := ctx.Value(credentialsContextKey{}).(*credentials); ok {
+		return creds, true
+	}
+	if session, ok := ctx.Value(sessionAuthContextKey{}).(*sessionAuth); ok {
+		creds, _ := session.current()
+		return creds, creds != nil
+	}
+	return nil, false
+}
+
+// AuthFromContext returns the authenticated caller of the request the context belongs to.
+// It is available to context-aware tool handlers on every transport, and reports false if
+// the caller did not authenticate.
+func AuthFromContext(ctx context.Context) (*mcptypes.AuthInfo, bool) {
+	if info, ok := ctx.Value(authContextKey{}).(*mcptypes.AuthInfo); ok && info != nil {
+		return info, true
+	}
+	if session, ok := ctx.Value(sessionAuthContextKey{}).(*sessionAuth); ok {
+		_, info := session.current()
+		return info, info != nil
+	}
+	return nil, false
+}
+
+// ContextWithAuth returns a context carrying the caller's AuthInfo, for custom transports
+// that authenticate callers themselves
+func ContextWithAuth(ctx context.Context, info *mcptypes.AuthInfo) context.Context {
+	return context.WithValue(ctx, authContextKey{}, info)
+}
+
+// bearerAuthInfo describes a caller from the context data returned by a bearer token validator
+func bearerAuthInfo(data map[string]any) *mcptypes.AuthInfo {
+	info := &mcptypes.AuthInfo{Method: mcptypes.AuthMethodBearer, Claims: data}
+	info.Subject, _ = data["sub"].(string)
+	info.Scopes = tokenScopes(data)
+	info.ExpiresAt = expiry(data["exp"])
+	return info
+}
+
+// expiry converts an "exp" value (seconds since the epoch, or a time.Time) to a time
+func expiry(value any) time.Time {
+	switch v := value.(type) {
+	case time.Time:
+		return v
+	case float64:
+		return time.Unix(int64(v), 0)
+	case int64:
+		return time.Unix(v, 0)
+	case int:
+		return time.Unix(int64(v), 0)
+	case json.Number:
+		if seconds, err := v.Int64(); err == nil {
+			return time.Unix(seconds, 0)
+		}
+	}
+	return time.Time{}
+}
+
+// tokenScopes returns the scopes granted by a token: the space-separated "scope" claim of
+// RFC 9068 and RFC 8693, or the "scp" claim used by some providers
+func tokenScopes(data map[string]any) []string {
+	var scopes []string
+	for _, name := range []string{"scope", "scp"} {
+		switch value := data[name].(type) {
+		case string:
+			scopes = append(scopes, strings.Fields(value)...)
+		case []string:
+			scopes = append(scopes, value...)
+		case []any:
+			for _, item := range value {
+				if scope, ok := item.(string); ok {
+					scopes = append(scopes, scope)
+				}
+			}
+		}
+	}
+	return scopes
+}
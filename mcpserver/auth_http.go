@@ -6,22 +6,28 @@
 package mcpserver
 
 import (
-	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
 )
 
-// bearerTokenHTTPMiddleware wraps an HTTP handler with bearer token authentication
+// bearerTokenHTTPMiddleware wraps an HTTP handler with bearer token authentication.
+// Rejected requests get a Bearer challenge (RFC 6750) that points to the protected
+// resource metadata, if configured.
 type bearerTokenHTTPMiddleware struct {
 	handler   http.Handler
 	validator mcptypes.BearerTokenValidator
 	logger    mcptypes.Logger
+	resource  *protectedResource // nil if no resource metadata is configured
+	scopes    []string           // Scopes every token must have
 }
 
 // newBearerTokenHTTPMiddleware creates a new bearer token HTTP middleware
-func newBearerTokenHTTPMiddleware(handler http.Handler, validator mcptypes.BearerTokenValidator, logger mcptypes.Logger) http.Handler {
+func newBearerTokenHTTPMiddleware(handler http.Handler, validator mcptypes.BearerTokenValidator, logger mcptypes.Logger) *bearerTokenHTTPMiddleware {
 	return &bearerTokenHTTPMiddleware{
 		handler:   handler,
 		validator: validator,
@@ -31,82 +37,86 @@ func newBearerTokenHTTPMiddleware(handler http.Handler, validator mcptypes.Beare
 
 // ServeHTTP implements http.Handler
 func (m *bearerTokenHTTPMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// Extract Authorization header
-	authHeader := r.Header.Get("Authorization")
-	if authHeader == "" {
-		m.logger.Warning("Missing Authorization header")
-		http.Error(w, "Authorization required", http.StatusUnauthorized)
-		return
+	creds := &credentials{validator: m.validator, scopes: m.scopes, request: true}
+	authorization := r.Header.Get("Authorization")
+	if authorization == "" {
+		// Browsers send the token of a WebSocket connection as a subprotocol
+		if token := webSocketToken(r); token != "" {
+			authorization = "Bearer " + token
+		}
 	}
-
-	// Check Bearer prefix
-	const prefix = "Bearer "
-	if !strings.HasPrefix(authHeader, prefix) {
-		m.logger.Warning("Invalid Authorization format")
-		http.Error(w, "Invalid Authorization format - expected Bearer token", http.StatusUnauthorized)
-		return
+	token, err := parseAuthorization(authorization)
+	var info *mcptypes.AuthInfo
+	if err == nil {
+		creds.token = token
+		info, err = creds.authenticate()
 	}
-
-	// Extract token
-	token := strings.TrimPrefix(authHeader, prefix)
-	token = strings.TrimSpace(token)
-
-	// Validate token
-	contextData, err := m.validator(token)
 	if err != nil {
-		m.logger.Warningf("Bearer token validation failed: %v", err)
-		http.Error(w, "Invalid token", http.StatusUnauthorized)
+		m.logger.Warningf("Bearer token authentication failed: %v", err)
+		m.reject(w, err)
 		return
 	}
 
-	// Store auth context in request context for downstream handlers
-	ctx := r.Context()
-	for key, value := range contextData {
-		ctx = context.WithValue(ctx, key, value)
-	}
-
-	// Create new request with enriched context and pass to handler
-	r = r.WithContext(ctx)
+	// Store the credentials and auth info in the request context for downstream handlers
+	r = r.WithContext(withCredentials(r.Context(), creds, info))
 	m.handler.ServeHTTP(w, r)
 }
 
-// authenticatedHTTPServer wraps an HTTP server with authentication
-type authenticatedHTTPServer struct {
-	server    *http.Server
-	validator mcptypes.BearerTokenValidator
-	logger    mcptypes.Logger
+// reject sends the challenge for an authentication failure
+func (m *bearerTokenHTTPMiddleware) reject(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errNoCredentials):
+		m.challenge(w, http.StatusUnauthorized, "", "Authorization required")
+	case errors.Is(err, errMalformedCredentials):
+		m.challenge(w, http.StatusBadRequest, "invalid_request", "Invalid Authorization format - expected Bearer token")
+	case errors.Is(err, errInsufficientScope):
+		m.challenge(w, http.StatusForbidden, "insufficient_scope", "Insufficient scope")
+	default:
+		m.challenge(w, http.StatusUnauthorized, "invalid_token", "Invalid token")
+	}
 }
 
-// Start starts the authenticated HTTP server
-func (a *authenticatedHTTPServer) Start(handler http.Handler) error {
-	// Wrap handler with authentication middleware
-	authHandler := newBearerTokenHTTPMiddleware(handler, a.validator, a.logger)
-
-	a.server = &http.Server{
-		Handler: authHandler,
+// challenge rejects a request with a WWW-Authenticate header. The error code is omitted when
+// the request had no credentials, as RFC 6750 requires.
+func (m *bearerTokenHTTPMiddleware) challenge(w http.ResponseWriter, status int, code, message string) {
+	var params []string
+	if code != "" {
+		params = append(params, fmt.Sprintf("error=%q", code), fmt.Sprintf("error_description=%q", message))
+	}
+	if len(m.scopes) > 0 {
+		params = append(params, fmt.Sprintf("scope=%q", strings.Join(m.scopes, " ")))
+	}
+	if m.resource != nil {
+		params = append(params, fmt.Sprintf("resource_metadata=%q", m.resource.metadataURL))
 	}
 
-	return a.server.ListenAndServe()
-}
-
-// Shutdown shuts down the server
-func (a *authenticatedHTTPServer) Shutdown(ctx context.Context) error {
-	if a.server != nil {
-		return a.server.Shutdown(ctx)
+	value := "Bearer"
+	if len(params) > 0 {
+		value += " " + strings.Join(params, ", ")
 	}
-	return nil
+	w.Header().Set("WWW-Authenticate", value)
+	http.Error(w, message, status)
 }
 
-// wrapHTTPHandlerWithAuth wraps an http.Handler with bearer token authentication
-func wrapHTTPHandlerWithAuth(handler http.Handler, validator mcptypes.BearerTokenValidator, logger mcptypes.Logger) http.Handler {
-	return newBearerTokenHTTPMiddleware(handler, validator, logger)
+// missingScopes returns the required scopes that were not granted
+func missingScopes(granted, required []string) []string {
+	var missing []string
+	for _, scope := range required {
+		if !slices.Contains(granted, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
 }
 
-// startHTTPServerWithHandler starts an HTTP server with the provided handler
-func startHTTPServerWithHandler(listen string, handler http.Handler) error {
-	srv := &http.Server{
-		Addr:    listen,
-		Handler: handler,
+// wrapHTTPHandlerWithAuth wraps an http.Handler with bearer token authentication. If resource
+// metadata is configured, the metadata document is served without authentication.
+func wrapHTTPHandlerWithAuth(handler http.Handler, validator mcptypes.BearerTokenValidator, logger mcptypes.Logger, resource *protectedResource, scopes []string) http.Handler {
+	auth := newBearerTokenHTTPMiddleware(handler, validator, logger)
+	auth.resource = resource
+	auth.scopes = scopes
+	if resource == nil {
+		return auth
 	}
-	return srv.ListenAndServe()
+	return &resourceMetadataHTTPMiddleware{handler: auth, resource: resource}
 }
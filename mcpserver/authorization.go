@@ -0,0 +1,125 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ErrForbidden is wrapped by the errors returned when a caller lacks the scopes or roles
+// required by a tool, resource or prompt
+var ErrForbidden = errors.New("forbidden")
+
+// defaultRoleClaims are the claims that roles are read from if WithRoleClaim is not set
+var defaultRoleClaims = []string{"roles", "role"}
+
+// authorize checks the caller's auth info against the scopes and roles required by a
+// definition. Every scope and at least one role must be granted; definitions that require
+// neither are open to everyone, including unauthenticated callers.
+func (m *MCPServer) authorize(ctx context.Context, scopes, roles []string) error {
+	if len(scopes) == 0 && len(roles) == 0 {
+		return nil
+	}
+	info, ok := AuthFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("%w: authentication required", ErrForbidden)
+	}
+	if missing := missingScopes(info.Scopes, scopes); len(missing) > 0 {
+		return fmt.Errorf("%w: missing scope %s", ErrForbidden, strings.Join(missing, " "))
+	}
+	if len(roles) > 0 {
+		granted := m.tokenRoles(info.Claims)
+		if !slices.ContainsFunc(roles, func(role string) bool { return slices.Contains(granted, role) }) {
+			return fmt.Errorf("%w: requires role %s", ErrForbidden, strings.Join(roles, " or "))
+		}
+	}
+	return nil
+}
+
+// tokenRoles returns the roles in the caller's claims, read from the configured claim (a
+// dotted path such as "realm_access.roles") or from "roles" or "role". A claim may be a list
+// or a space-separated string.
+func (m *MCPServer) tokenRoles(data map[string]any) []string {
+	claims := defaultRoleClaims
+	if m.roleClaim != "" {
+		claims = []string{m.roleClaim}
+	}
+
+	var roles []string
+	for _, claim := range claims {
+		var value any = data
+		for _, name := range strings.Split(claim, ".") {
+			object, _ := value.(map[string]any)
+			value = object[name]
+		}
+		switch v := value.(type) {
+		case string:
+			roles = append(roles, strings.Fields(v)...)
+		case []string:
+			roles = append(roles, v...)
+		case []any:
+			for _, item := range v {
+				if role, ok := item.(string); ok {
+					roles = append(roles, role)
+				}
+			}
+		}
+	}
+	return roles
+}
+
+// allowedTools removes the tools the caller may not call from a tools/list result
+func (m *MCPServer) allowedTools(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+	m.registryMu.Lock()
+	defer m.registryMu.Unlock()
+	return slices.DeleteFunc(tools, func(tool mcp.Tool) bool {
+		def := m.tools[tool.Name]
+		return m.authorize(ctx, def.Scopes, def.Roles) != nil
+	})
+}
+
+// allowedResources removes the resources the caller may not read from a resources/list result
+func (m *MCPServer) allowedResources(ctx context.Context, resources []mcp.Resource) []mcp.Resource {
+	m.registryMu.Lock()
+	defer m.registryMu.Unlock()
+	return slices.DeleteFunc(resources, func(resource mcp.Resource) bool {
+		def := m.resources[resource.URI]
+		return m.authorize(ctx, def.Scopes, def.Roles) != nil
+	})
+}
+
+// allowedResourceTemplates removes the templates the caller may not read from a
+// resources/templates/list result
+func (m *MCPServer) allowedResourceTemplates(ctx context.Context, templates []mcp.ResourceTemplate) []mcp.ResourceTemplate {
+	m.registryMu.Lock()
+	defer m.registryMu.Unlock()
+	return slices.DeleteFunc(templates, func(template mcp.ResourceTemplate) bool {
+		if template.URITemplate == nil {
+			return false
+		}
+		def, ok := m.templates[template.URITemplate.Raw()]
+		if !ok {
+			return true // Removed (see removedServerResourceTemplate)
+		}
+		return m.authorize(ctx, def.Scopes, def.Roles) != nil
+	})
+}
+
+// allowedPrompts removes the prompts the caller may not get from a prompts/list result
+func (m *MCPServer) allowedPrompts(ctx context.Context, prompts []mcp.Prompt) []mcp.Prompt {
+	m.registryMu.Lock()
+	defer m.registryMu.Unlock()
+	return slices.DeleteFunc(prompts, func(prompt mcp.Prompt) bool {
+		def := m.prompts[prompt.Name]
+		return m.authorize(ctx, def.Scopes, def.Roles) != nil
+	})
+}
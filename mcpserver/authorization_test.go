@@ -0,0 +1,286 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcpserver"
+	"github.com/PivotLLM/MCPLaunchPad/mcptest"
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// guardedProvider offers one open and several protected tools, resources and prompts
+type guardedProvider struct{}
+
+func (guardedProvider) RegisterTools() []mcptypes.ToolDefinition {
+	handler := func(map[string]any) (string, error) { return "ok", nil }
+	return []mcptypes.ToolDefinition{
+		{Name: "open", Description: "Open", Handler: handler},
+		{Name: "read", Description: "Needs read", Handler: handler, Scopes: []string{"read"}},
+		{Name: "write", Description: "Needs read and write", Handler: handler, Scopes: []string{"read", "write"}},
+		{Name: "admin", Description: "Needs admin or owner", Handler: handler, Roles: []string{"admin", "owner"}},
+	}
+}
+
+func (guardedProvider) RegisterResources() []mcptypes.ResourceDefinition {
+	handler := func(uri string, _ map[string]any) (mcptypes.ResourceResponse, error) {
+		return mcptypes.ResourceResponse{URI: uri, MIMEType: "text/plain", Content: "ok"}, nil
+	}
+	return []mcptypes.ResourceDefinition{
+		{Name: "public", URI: "test://public", MIMEType: "text/plain", Handler: handler},
+		{Name: "private", URI: "test://private", MIMEType: "text/plain", Handler: handler, Scopes: []string{"read"}},
+	}
+}
+
+func (guardedProvider) RegisterResourceTemplates() []mcptypes.ResourceTemplateDefinition {
+	handler := func(uri string, _ map[string]any) (mcptypes.ResourceResponse, error) {
+		return mcptypes.ResourceResponse{URI: uri, MIMEType: "text/plain", Content: "ok"}, nil
+	}
+	return []mcptypes.ResourceTemplateDefinition{
+		{Name: "items", URITemplate: "test://items/{id}", MIMEType: "text/plain", Handler: handler, Roles: []string{"admin"}},
+	}
+}
+
+func (guardedProvider) RegisterPrompts() []mcptypes.PromptDefinition {
+	handler := func(map[string]any) (string, mcptypes.Messages, error) {
+		return "ok", mcptypes.Messages{{Role: "user", Content: "ok"}}, nil
+	}
+	return []mcptypes.PromptDefinition{
+		{Name: "hello", Description: "Open", Handler: handler},
+		{Name: "audit", Description: "Needs auditor", Handler: handler, Roles: []string{"auditor"}},
+	}
+}
+
+// tokens maps test tokens to the claims the validator returns for them
+var tokens = map[string]map[string]any{
+	"reader":  {"sub": "reader", "scope": "read"},
+	"writer":  {"sub": "writer", "scp": []any{"read", "write"}},
+	"admin":   {"sub": "admin", "roles": []any{"admin"}},
+	"auditor": {"sub": "auditor", "role": "auditor", "realm": map[string]any{"roles": []any{"admin"}}},
+	"nobody":  {"sub": "nobody"},
+}
+
+func validateTestToken(token string) (map[string]any, error) {
+	if claims, ok := tokens[token]; ok {
+		return claims, nil
+	}
+	return nil, errors.New("unknown token")
+}
+
+func newGuardedClient(t *testing.T, token string, options ...mcpserver.Option) *mcptest.Client {
+	t.Helper()
+	p := guardedProvider{}
+	options = append([]mcpserver.Option{
+		mcpserver.WithToolProviders([]mcptypes.ToolProvider{p}),
+		mcpserver.WithResourceProviders([]mcptypes.ResourceProvider{p}),
+		mcpserver.WithPromptProviders([]mcptypes.PromptProvider{p}),
+	}, options...)
+	if token == "" {
+		return mcptest.NewClient(t, options...)
+	}
+	return mcptest.NewAuthenticatedClient(t, token, append(options, mcpserver.WithBearerTokenAuth(validateTestToken))...)
+}
+
+func TestAuthorizationFiltersLists(t *testing.T) {
+	tests := []struct {
+		token     string
+		tools     []string
+		resources []string
+		templates []string
+		prompts   []string
+	}{
+		{token: "", tools: []string{"open"}, resources: []string{"test://public"}, prompts: []string{"hello"}},
+		{token: "nobody", tools: []string{"open"}, resources: []string{"test://public"}, prompts: []string{"hello"}},
+		{token: "reader", tools: []string{"open", "read"}, resources: []string{"test://private", "test://public"}, prompts: []string{"hello"}},
+		{token: "writer", tools: []string{"open", "read", "write"}, resources: []string{"test://private", "test://public"}, prompts: []string{"hello"}},
+		{token: "admin", tools: []string{"admin", "open"}, resources: []string{"test://public"}, templates: []string{"test://items/{id}"}, prompts: []string{"hello"}},
+		{token: "auditor", tools: []string{"open"}, resources: []string{"test://public"}, prompts: []string{"audit", "hello"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("token="+tt.token, func(t *testing.T) {
+			c := newGuardedClient(t, tt.token)
+
+			var tools, resources, templates, prompts []string
+			for _, tool := range c.ListTools() {
+				tools = append(tools, tool.Name)
+			}
+			for _, resource := range c.ListResources() {
+				resources = append(resources, resource.URI)
+			}
+			for _, template := range c.ListResourceTemplates() {
+				templates = append(templates, template.URITemplate.Raw())
+			}
+			for _, prompt := range c.ListPrompts() {
+				prompts = append(prompts, prompt.Name)
+			}
+			assertNames(t, "tools", tools, tt.tools)
+			assertNames(t, "resources", resources, tt.resources)
+			assertNames(t, "templates", templates, tt.templates)
+			assertNames(t, "prompts", prompts, tt.prompts)
+		})
+	}
+}
+
+func TestAuthorizationEnforcesCalls(t *testing.T) {
+	tests := []struct {
+		token   string
+		tool    string
+		allowed bool
+	}{
+		{"", "open", true},
+		{"", "read", false},
+		{"nobody", "read", false},
+		{"reader", "read", true},
+		{"reader", "write", false},
+		{"writer", "write", true},
+		{"reader", "admin", false},
+		{"admin", "admin", true},
+		{"auditor", "admin", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token+"/"+tt.tool, func(t *testing.T) {
+			c := newGuardedClient(t, tt.token)
+			result, err := c.CallToolErr(tt.tool, nil)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("call failed: %v", err)
+				}
+				mcptest.AssertText(t, result, "ok")
+			} else if err == nil {
+				t.Fatalf("call was allowed: %v", mcptest.ResultText(result))
+			}
+		})
+	}
+}
+
+func TestAuthorizationEnforcesResourcesAndPrompts(t *testing.T) {
+	c := newGuardedClient(t, "reader")
+	ctx := context.Background()
+
+	read := func(uri string) error {
+		request := mcp.ReadResourceRequest{}
+		request.Params.URI = uri
+		_, err := c.MCPClient().ReadResource(ctx, request)
+		return err
+	}
+	if err := read("test://private"); err != nil {
+		t.Errorf("reading a permitted resource failed: %v", err)
+	}
+	if err := read("test://items/1"); err == nil {
+		t.Error("reading a template without the role was allowed")
+	}
+
+	request := mcp.GetPromptRequest{}
+	request.Params.Name = "audit"
+	if _, err := c.MCPClient().GetPrompt(ctx, request); err == nil {
+		t.Error("getting a prompt without the role was allowed")
+	}
+	request.Params.Name = "hello"
+	if _, err := c.MCPClient().GetPrompt(ctx, request); err != nil {
+		t.Errorf("getting an open prompt failed: %v", err)
+	}
+}
+
+func TestRoleClaim(t *testing.T) {
+	// The auditor's nested realm.roles claim grants admin once it is the configured role claim
+	c := newGuardedClient(t, "auditor", mcpserver.WithRoleClaim("realm.roles"))
+	result, err := c.CallToolErr("admin", nil)
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	mcptest.AssertText(t, result, "ok")
+
+	var prompts []string
+	for _, prompt := range c.ListPrompts() {
+		prompts = append(prompts, prompt.Name)
+	}
+	assertNames(t, "prompts", prompts, []string{"hello"}) // The top-level role claim is no longer read
+}
+
+func assertNames(t *testing.T, kind string, got, want []string) {
+	t.Helper()
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Errorf("%s = %v, want %v", kind, got, want)
+	}
+}
+
+// callerProvider offers a resource, a resource template and a prompt with context-aware
+// handlers that report the caller
+type callerProvider struct{}
+
+func caller(ctx context.Context) string {
+	if info, ok := mcpserver.AuthFromContext(ctx); ok {
+		return info.Subject
+	}
+	return "anonymous"
+}
+
+func (callerProvider) RegisterResources() []mcptypes.ResourceDefinition {
+	return []mcptypes.ResourceDefinition{{
+		Name: "caller", URI: "test://caller", MIMEType: "text/plain",
+		HandlerContext: func(ctx context.Context, uri string, _ map[string]any) (mcptypes.ResourceResponse, error) {
+			return mcptypes.ResourceResponse{URI: uri, MIMEType: "text/plain", Content: caller(ctx)}, nil
+		},
+	}}
+}
+
+func (callerProvider) RegisterResourceTemplates() []mcptypes.ResourceTemplateDefinition {
+	return []mcptypes.ResourceTemplateDefinition{{
+		Name: "callers", URITemplate: "test://callers/{id}", MIMEType: "text/plain",
+		HandlerContext: func(ctx context.Context, uri string, _ map[string]any) (mcptypes.ResourceResponse, error) {
+			return mcptypes.ResourceResponse{URI: uri, MIMEType: "text/plain", Content: caller(ctx)}, nil
+		},
+	}}
+}
+
+func (callerProvider) RegisterPrompts() []mcptypes.PromptDefinition {
+	return []mcptypes.PromptDefinition{{
+		Name: "caller",
+		HandlerContext: func(ctx context.Context, _ map[string]any) (string, mcptypes.Messages, error) {
+			return "caller", mcptypes.Messages{{Role: "user", Content: caller(ctx)}}, nil
+		},
+	}}
+}
+
+func TestContextHandlersReceiveCaller(t *testing.T) {
+	p := callerProvider{}
+	c := mcptest.NewAuthenticatedClient(t, "reader",
+		mcpserver.WithBearerTokenAuth(validateTestToken),
+		mcpserver.WithResourceProviders([]mcptypes.ResourceProvider{p}),
+		mcpserver.WithPromptProviders([]mcptypes.PromptProvider{p}))
+	ctx := context.Background()
+
+	for _, uri := range []string{"test://caller", "test://callers/1"} {
+		request := mcp.ReadResourceRequest{}
+		request.Params.URI = uri
+		result, err := c.MCPClient().ReadResource(ctx, request)
+		if err != nil {
+			t.Fatalf("reading %s failed: %v", uri, err)
+		}
+		if text := result.Contents[0].(mcp.TextResourceContents).Text; text != "reader" {
+			t.Errorf("%s = %q, want reader", uri, text)
+		}
+	}
+
+	request := mcp.GetPromptRequest{}
+	request.Params.Name = "caller"
+	result, err := c.MCPClient().GetPrompt(ctx, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text := result.Messages[0].Content.(mcp.TextContent).Text; text != "reader" {
+		t.Errorf("prompt message = %q, want reader", text)
+	}
+}
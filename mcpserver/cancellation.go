@@ -0,0 +1,106 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// cancelledNotification is sent by a client to cancel a request it made earlier.
+// mcp-go does not act on it, so the server tracks tool calls itself.
+const cancelledNotification = "notifications/cancelled"
+
+// callKey identifies a tool call by session and JSON-RPC request ID
+type callKey struct {
+	session string
+	request string
+}
+
+// inFlightCall is a tool call that can be cancelled by the client
+type inFlightCall struct {
+	cancel context.CancelFunc
+}
+
+// trackCall returns a context that is cancelled when the client cancels the tool call, and a
+// function to call when the call completes
+func (m *MCPServer) trackCall(ctx context.Context, req mcp.CallToolRequest) (context.Context, func()) {
+	var requestID string
+	if req.Params.Meta != nil {
+		requestID, _ = req.Params.Meta.AdditionalFields[requestIDMetaKey].(string)
+	}
+	if requestID == "" {
+		return ctx, func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	key := callKey{session: sessionID(ctx), request: requestID}
+	call := &inFlightCall{cancel: cancel}
+	m.callsMu.Lock()
+	m.calls[key] = call
+	m.callsMu.Unlock()
+
+	return ctx, func() {
+		m.callsMu.Lock()
+		// A client reusing the request ID may have replaced the entry
+		if m.calls[key] == call {
+			delete(m.calls, key)
+		}
+		m.callsMu.Unlock()
+		cancel()
+	}
+}
+
+// handleCancelled cancels the tool call named by a notifications/cancelled notification.
+// Calls of other sessions, and calls that already completed, are not affected.
+func (m *MCPServer) handleCancelled(ctx context.Context, notification mcp.JSONRPCNotification) {
+	requestID := requestIDString(notification.Params.AdditionalFields["requestId"])
+	if requestID == "" {
+		return
+	}
+
+	m.callsMu.Lock()
+	call := m.calls[callKey{session: sessionID(ctx), request: requestID}]
+	m.callsMu.Unlock()
+	if call == nil {
+		return
+	}
+
+	reason, _ := notification.Params.AdditionalFields["reason"].(string)
+	m.logger.Infof("Request %s cancelled by the client: %s", requestID, reason)
+	call.cancel()
+}
+
+// sessionID returns the ID of the MCP session a request belongs to, or "" if there is none
+func sessionID(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return ""
+}
+
+// requestIDString returns a JSON-RPC request ID as a string, e.g. "7" or "abc", or "" if
+// there is none
+func requestIDString(id any) string {
+	switch v := id.(type) {
+	case mcp.RequestId:
+		return requestIDString(v.Value())
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
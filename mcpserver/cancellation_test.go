@@ -0,0 +1,146 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver_test
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcpserver"
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// connectHTTP initializes a streamable HTTP client session with the server at url
+func connectHTTP(t *testing.T, url string, headers map[string]string) *client.Client {
+	t.Helper()
+	c, err := client.NewStreamableHttpClient(url, transport.WithHTTPHeaders(headers))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err = c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	request := mcp.InitializeRequest{}
+	request.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	if _, err = c.Initialize(ctx, request); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// sendCancelled sends notifications/cancelled for a request ID
+func sendCancelled(t *testing.T, c *client.Client, requestID string) {
+	t.Helper()
+	id, err := strconv.Atoi(requestID)
+	if err != nil {
+		t.Fatalf("request ID %q is not a number", requestID)
+	}
+	notification := mcp.JSONRPCNotification{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		Notification: mcp.Notification{
+			Method: "notifications/cancelled",
+			Params: mcp.NotificationParams{AdditionalFields: map[string]any{"requestId": id, "reason": "test"}},
+		},
+	}
+	if err = c.GetTransport().SendNotification(context.Background(), notification); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestToolHandlerContext(t *testing.T) {
+	requests := make(chan mcptypes.ToolRequest, 1)
+	wait := mcptypes.ToolDefinition{
+		Name: "wait",
+		HandlerContext: func(ctx context.Context, req mcptypes.ToolRequest, _ map[string]any) (string, error) {
+			requests <- req
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(5 * time.Second):
+				return "not cancelled", nil
+			}
+		},
+	}
+	validator := func(token string) (map[string]any, error) {
+		if token != "secret" {
+			return nil, fmt.Errorf("unknown token")
+		}
+		return map[string]any{"sub": "alice"}, nil
+	}
+	m, err := mcpserver.New(
+		mcpserver.WithHandlerOnly(),
+		mcpserver.WithBearerTokenAuth(validator),
+		mcpserver.WithToolProviders([]mcptypes.ToolProvider{listTools{wait}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(m.StreamableHandler())
+	t.Cleanup(func() {
+		_ = m.Stop()
+		ts.Close()
+	})
+
+	headers := map[string]string{"Authorization": "Bearer secret"}
+	c := connectHTTP(t, ts.URL+m.StreamableEndpoint(), headers)
+	other := connectHTTP(t, ts.URL+m.StreamableEndpoint(), headers)
+
+	results := make(chan *mcp.CallToolResult, 1)
+	go func() {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = "wait"
+		result, err := c.CallTool(context.Background(), request)
+		if err != nil {
+			result = mcp.NewToolResultError(err.Error())
+		}
+		results <- result
+	}()
+
+	// The handler receives the session, request ID and caller of the call
+	var req mcptypes.ToolRequest
+	select {
+	case req = <-requests:
+	case <-time.After(5 * time.Second):
+		t.Fatal("tool not called")
+	}
+	if req.ToolName != "wait" || req.SessionID != c.GetSessionId() || req.RequestID == "" {
+		t.Errorf("request = %+v, want wait in session %s with a request ID", req, c.GetSessionId())
+	}
+	if req.AuthInfo == nil || req.AuthInfo.Subject != "alice" {
+		t.Errorf("auth info = %+v, want alice", req.AuthInfo)
+	}
+
+	// Another session cannot cancel the call
+	sendCancelled(t, other, req.RequestID)
+	select {
+	case result := <-results:
+		t.Fatalf("call ended after another session cancelled it: %+v", result)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	sendCancelled(t, c, req.RequestID)
+	select {
+	case result := <-results:
+		text := result.Content[0].(mcp.TextContent).Text
+		if !result.IsError || !strings.Contains(text, context.Canceled.Error()) {
+			t.Errorf("result = %q, want the handler to see its context cancelled", text)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("call not cancelled")
+	}
+}
@@ -0,0 +1,171 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// CoercionPolicy controls which argument type conversions are applied, based on
+// Parameter.Type, before arguments are validated and passed to the handler.
+// The zero value performs no coercion.
+type CoercionPolicy struct {
+	StringToNumber  bool // "42" becomes 42 for number and integer parameters
+	FloatToInteger  bool // 42.0 becomes int64(42) for integer parameters
+	StringToBoolean bool // "true"/"false" (per strconv.ParseBool) becomes a bool for boolean parameters
+	NumberToString  bool // 42 becomes "42" for string parameters
+}
+
+// CoerceAll is a CoercionPolicy with every conversion enabled
+var CoerceAll = CoercionPolicy{
+	StringToNumber:  true,
+	FloatToInteger:  true,
+	StringToBoolean: true,
+	NumberToString:  true,
+}
+
+// prepareArguments returns a copy of the arguments with declared defaults filled in for
+// missing optional parameters and the coercion policy applied
+func (m *MCPServer) prepareArguments(params []*mcptypes.Parameter, args map[string]any) map[string]any {
+	prepared := make(map[string]any, len(args))
+	for key, value := range args {
+		prepared[key] = value
+	}
+
+	for _, param := range params {
+		value, exists := prepared[param.Name]
+		if !exists {
+			if param.Default == nil || param.Required {
+				continue
+			}
+			value = copyDefault(param.Default)
+		}
+		prepared[param.Name] = m.coercion.coerce(param, value)
+	}
+	return prepared
+}
+
+// coerce converts a single value according to the parameter schema, recursing into arrays and objects
+func (p CoercionPolicy) coerce(param *mcptypes.Parameter, value any) any {
+	switch param.Type {
+	case "number":
+		if s, ok := value.(string); ok && p.StringToNumber {
+			if f, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+				return f
+			}
+		}
+
+	case "integer":
+		if s, ok := value.(string); ok && p.StringToNumber {
+			if n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64); err == nil {
+				if p.FloatToInteger {
+					return n
+				}
+				return float64(n)
+			}
+		}
+		if f, ok := value.(float64); ok && p.FloatToInteger {
+			// float64(math.MaxInt64) rounds up to 2^63, which does not fit, so the upper bound is exclusive
+			if f == math.Trunc(f) && f >= -1<<63 && f < 1<<63 {
+				return int64(f)
+			}
+		}
+
+	case "boolean":
+		if s, ok := value.(string); ok && p.StringToBoolean {
+			if b, err := strconv.ParseBool(strings.TrimSpace(s)); err == nil {
+				return b
+			}
+		}
+
+	case "string":
+		if p.NumberToString {
+			if _, isString := value.(string); !isString {
+				if f, ok := toFloat(value); ok {
+					return strconv.FormatFloat(f, 'f', -1, 64)
+				}
+			}
+		}
+
+	case "array":
+		items, ok := value.([]any)
+		if !ok || param.Items == nil {
+			return value
+		}
+		coerced := make([]any, len(items))
+		for i, item := range items {
+			coerced[i] = p.coerce(param.Items, item)
+		}
+		return coerced
+
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok || len(param.Properties) == 0 {
+			return value
+		}
+		coerced := make(map[string]any, len(object))
+		for key, v := range object {
+			coerced[key] = v
+		}
+		for name, prop := range param.Properties {
+			v, exists := coerced[name]
+			if !exists {
+				if prop.Default == nil || prop.Required {
+					continue
+				}
+				v = copyDefault(prop.Default)
+			}
+			coerced[name] = p.coerce(prop, v)
+		}
+		return coerced
+	}
+
+	return value
+}
+
+// copyDefault returns a deep copy of a default value, so that a handler modifying a slice or
+// map it received cannot change the default seen by later calls
+func copyDefault(value any) any {
+	return deepCopy(reflect.ValueOf(value)).Interface()
+}
+
+// deepCopy copies slices and maps recursively; other values are returned as they are
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(deepCopy(v.Elem()))
+		return copied
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			copied.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return copied
+	}
+	return v
+}
@@ -0,0 +1,150 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+func TestCoerce(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy CoercionPolicy
+		param  *mcptypes.Parameter
+		value  any
+		want   any
+	}{
+		{"no policy leaves strings", CoercionPolicy{}, mcptypes.NumberParam("n", "", true), "42", "42"},
+		{"string to number", CoercionPolicy{StringToNumber: true}, mcptypes.NumberParam("n", "", true), " 4.5 ", 4.5},
+		{"invalid number unchanged", CoercionPolicy{StringToNumber: true}, mcptypes.NumberParam("n", "", true), "four", "four"},
+		{"string to integer as float", CoercionPolicy{StringToNumber: true}, mcptypes.IntegerParam("i", "", true), "42", 42.0},
+		{"string to integer as int64", CoercionPolicy{StringToNumber: true, FloatToInteger: true}, mcptypes.IntegerParam("i", "", true), "42", int64(42)},
+		{"fractional string not an integer", CoercionPolicy{StringToNumber: true}, mcptypes.IntegerParam("i", "", true), "4.2", "4.2"},
+		{"float to integer", CoercionPolicy{FloatToInteger: true}, mcptypes.IntegerParam("i", "", true), 42.0, int64(42)},
+		{"fraction not truncated", CoercionPolicy{FloatToInteger: true}, mcptypes.IntegerParam("i", "", true), 42.5, 42.5},
+		{"2^63 out of range", CoercionPolicy{FloatToInteger: true}, mcptypes.IntegerParam("i", "", true), float64(1 << 63), float64(1 << 63)},
+		{"-2^63 in range", CoercionPolicy{FloatToInteger: true}, mcptypes.IntegerParam("i", "", true), float64(-1 << 63), int64(math.MinInt64)},
+		{"float left without flag", CoercionPolicy{}, mcptypes.IntegerParam("i", "", true), 42.0, 42.0},
+		{"string to boolean", CoercionPolicy{StringToBoolean: true}, mcptypes.BoolParam("b", "", true), "true", true},
+		{"string to false", CoercionPolicy{StringToBoolean: true}, mcptypes.BoolParam("b", "", true), "0", false},
+		{"invalid boolean unchanged", CoercionPolicy{StringToBoolean: true}, mcptypes.BoolParam("b", "", true), "yes", "yes"},
+		{"boolean left without flag", CoercionPolicy{}, mcptypes.BoolParam("b", "", true), "true", "true"},
+		{"number to string", CoercionPolicy{NumberToString: true}, mcptypes.StringParam("s", "", true), 42.0, "42"},
+		{"fraction to string", CoercionPolicy{NumberToString: true}, mcptypes.StringParam("s", "", true), 0.5, "0.5"},
+		{"string left as string", CoercionPolicy{NumberToString: true}, mcptypes.StringParam("s", "", true), "x", "x"},
+		{"number left without flag", CoercionPolicy{}, mcptypes.StringParam("s", "", true), 42.0, 42.0},
+		{
+			"array items", CoerceAll,
+			mcptypes.ArrayParam("a", "", true, mcptypes.IntegerParam("", "", false)),
+			[]any{"1", 2.0}, []any{int64(1), int64(2)},
+		},
+		{
+			"object properties", CoerceAll,
+			mcptypes.ObjectParam("o", "", true, map[string]*mcptypes.Parameter{
+				"n":     mcptypes.NumberParam("n", "", true),
+				"flag":  mcptypes.BoolParam("flag", "", false).WithDefault(true),
+				"extra": mcptypes.StringParam("extra", "", false),
+			}),
+			map[string]any{"n": "1.5", "other": "kept"},
+			map[string]any{"n": 1.5, "flag": true, "other": "kept"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.coerce(tt.param, tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("coerce(%#v) = %#v, want %#v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrepareArguments(t *testing.T) {
+	params := []*mcptypes.Parameter{
+		mcptypes.StringParam("unit", "", false).WithDefault("metric"),
+		mcptypes.IntegerParam("days", "", false).WithDefault(3.0),
+		mcptypes.StringParam("city", "", true).WithDefault("ignored"),
+		mcptypes.BoolParam("verbose", "", false),
+	}
+
+	tests := []struct {
+		name   string
+		policy CoercionPolicy
+		args   map[string]any
+		want   map[string]any
+	}{
+		{
+			name: "defaults fill missing optional parameters",
+			args: map[string]any{"city": "Ottawa"},
+			want: map[string]any{"city": "Ottawa", "unit": "metric", "days": 3.0},
+		},
+		{
+			name: "supplied values win",
+			args: map[string]any{"city": "Ottawa", "unit": "imperial", "days": 5.0},
+			want: map[string]any{"city": "Ottawa", "unit": "imperial", "days": 5.0},
+		},
+		{
+			name: "required parameters get no default",
+			args: map[string]any{},
+			want: map[string]any{"unit": "metric", "days": 3.0},
+		},
+		{
+			name:   "defaults are coerced",
+			policy: CoercionPolicy{FloatToInteger: true, StringToBoolean: true},
+			args:   map[string]any{"city": "Ottawa", "verbose": "true"},
+			want:   map[string]any{"city": "Ottawa", "unit": "metric", "days": int64(3), "verbose": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &MCPServer{coercion: tt.policy}
+			original := make(map[string]any, len(tt.args))
+			for k, v := range tt.args {
+				original[k] = v
+			}
+			got := m.prepareArguments(params, tt.args)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("prepareArguments = %#v, want %#v", got, tt.want)
+			}
+			if !reflect.DeepEqual(tt.args, original) {
+				t.Errorf("arguments were modified: %#v", tt.args)
+			}
+		})
+	}
+}
+
+func TestPrepareArgumentsCopiesDefaults(t *testing.T) {
+	params := []*mcptypes.Parameter{
+		mcptypes.ArrayParam("tags", "", false, mcptypes.StringParam("", "", false)).WithDefault([]any{"a"}),
+		mcptypes.ArrayParam("names", "", false, nil).WithDefault([]string{"x"}),
+		mcptypes.ObjectParam("options", "", false, map[string]*mcptypes.Parameter{
+			"sizes": mcptypes.ArrayParam("sizes", "", false, nil).WithDefault([]any{1.0}),
+		}).WithDefault(map[string]any{"nested": map[string]any{"on": true}}),
+	}
+	m := &MCPServer{}
+
+	// A handler modifying the arguments it received must not change the defaults
+	first := m.prepareArguments(params, map[string]any{})
+	first["tags"].([]any)[0] = "changed"
+	first["names"].([]string)[0] = "changed"
+	options := first["options"].(map[string]any)
+	options["nested"].(map[string]any)["on"] = false
+	options["sizes"].([]any)[0] = 2.0
+
+	want := map[string]any{
+		"tags":    []any{"a"},
+		"names":   []string{"x"},
+		"options": map[string]any{"nested": map[string]any{"on": true}, "sizes": []any{1.0}},
+	}
+	if got := m.prepareArguments(params, map[string]any{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("prepareArguments after modification = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,165 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// syncToolProvider registers the current tools of a provider, removing any tools it
+// registered previously but no longer returns
+func (m *MCPServer) syncToolProvider(index int) {
+	m.providerMu.Lock()
+	defer m.providerMu.Unlock()
+
+	var add []mcptypes.ToolDefinition
+	var names []string
+	for _, toolDef := range m.toolProviders[index].RegisterTools() {
+		if err := checkToolDefinition(toolDef); err != nil {
+			m.logger.Errorf("Unable to register tool: %v", err)
+			continue
+		}
+		add = append(add, toolDef)
+		names = append(names, toolDef.Name)
+	}
+
+	remove := removedNames(m.providerTools[index], names)
+	if _, err := m.applyTools(source(index), add, remove); err != nil {
+		m.logger.Errorf("Unable to update tools: %v", err)
+		return
+	}
+	m.providerTools[index] = names
+}
+
+// syncResourceProvider registers the current resources and resource templates of a provider,
+// removing any it registered previously but no longer returns
+func (m *MCPServer) syncResourceProvider(index int) {
+	m.providerMu.Lock()
+	defer m.providerMu.Unlock()
+
+	provider := m.resourceProviders[index]
+
+	var resources []mcptypes.ResourceDefinition
+	var uris []string
+	for _, resource := range provider.RegisterResources() {
+		if err := checkResourceDefinition(resource); err != nil {
+			m.logger.Errorf("Unable to register resource: %v", err)
+			continue
+		}
+		resources = append(resources, resource)
+		uris = append(uris, resource.URI)
+	}
+
+	var templates []mcptypes.ResourceTemplateDefinition
+	var uriTemplates []string
+	for _, resourceTemplate := range provider.RegisterResourceTemplates() {
+		if err := checkResourceTemplateDefinition(resourceTemplate); err != nil {
+			m.logger.Errorf("Unable to register resource template: %v", err)
+			continue
+		}
+		templates = append(templates, resourceTemplate)
+		uriTemplates = append(uriTemplates, resourceTemplate.URITemplate)
+	}
+
+	// Resources and templates are updated under one lock and share the list_changed
+	// notification, so clients are notified once for both
+	m.registryMu.Lock()
+	defer m.registryMu.Unlock()
+	removed := m.updateResources(source(index), resources, removedNames(m.providerResources[index], uris))
+	removed += m.updateResourceTemplates(source(index), templates, removedNames(m.providerTemplates[index], uriTemplates))
+	if len(resources) > 0 || len(templates) > 0 || removed > 0 {
+		m.srv.SendNotificationToAllClients(mcp.MethodNotificationResourcesListChanged, nil)
+	}
+	m.providerResources[index] = uris
+	m.providerTemplates[index] = uriTemplates
+}
+
+// syncPromptProvider registers the current prompts of a provider, removing any prompts it
+// registered previously but no longer returns
+func (m *MCPServer) syncPromptProvider(index int) {
+	m.providerMu.Lock()
+	defer m.providerMu.Unlock()
+
+	var add []mcptypes.PromptDefinition
+	var names []string
+	for _, prompt := range m.promptProviders[index].RegisterPrompts() {
+		if err := checkPromptDefinition(prompt); err != nil {
+			m.logger.Errorf("Unable to register prompt: %v", err)
+			continue
+		}
+		add = append(add, prompt)
+		names = append(names, prompt.Name)
+	}
+
+	remove := removedNames(m.providerPrompts[index], names)
+	if _, err := m.applyPrompts(source(index), add, remove); err != nil {
+		m.logger.Errorf("Unable to update prompts: %v", err)
+		return
+	}
+	m.providerPrompts[index] = names
+}
+
+// watchProviders subscribes to every provider that implements a Dynamic*Provider interface.
+// Each watcher re-syncs its provider until the channel is closed or ctx is cancelled.
+func (m *MCPServer) watchProviders(ctx context.Context) {
+	for i, provider := range m.toolProviders {
+		if dynamic, ok := provider.(mcptypes.DynamicToolProvider); ok {
+			m.watch(ctx, dynamic.Watch(ctx), func() { m.syncToolProvider(i) })
+		}
+	}
+	for i, provider := range m.resourceProviders {
+		if dynamic, ok := provider.(mcptypes.DynamicResourceProvider); ok {
+			m.watch(ctx, dynamic.Watch(ctx), func() { m.syncResourceProvider(i) })
+		}
+	}
+	for i, provider := range m.promptProviders {
+		if dynamic, ok := provider.(mcptypes.DynamicPromptProvider); ok {
+			m.watch(ctx, dynamic.Watch(ctx), func() { m.syncPromptProvider(i) })
+		}
+	}
+}
+
+// watch calls sync each time the channel receives, until it is closed or ctx is cancelled
+func (m *MCPServer) watch(ctx context.Context, changes <-chan struct{}, sync func()) {
+	if changes == nil {
+		return
+	}
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-changes:
+				if !ok {
+					return
+				}
+				m.logger.Debug("Provider reported a change")
+				sync()
+			}
+		}
+	}()
+}
+
+// removedNames returns the entries of previous that are not in current
+func removedNames(previous, current []string) []string {
+	keep := make(map[string]bool, len(current))
+	for _, name := range current {
+		keep[name] = true
+	}
+	var removed []string
+	for _, name := range previous {
+		if !keep[name] {
+			removed = append(removed, name)
+		}
+	}
+	return removed
+}
@@ -0,0 +1,295 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcpserver"
+	"github.com/PivotLLM/MCPLaunchPad/mcptest"
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// dynamicProvider returns the tools it holds and reports each change on its watch channel
+type dynamicProvider struct {
+	mu      sync.Mutex
+	tools   []string
+	changes chan struct{}
+}
+
+func (p *dynamicProvider) RegisterTools() []mcptypes.ToolDefinition {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var tools []mcptypes.ToolDefinition
+	for _, name := range p.tools {
+		tools = append(tools, mcptypes.ToolDefinition{
+			Name:    name,
+			Handler: func(map[string]any) (string, error) { return name, nil },
+		})
+	}
+	return tools
+}
+
+func (p *dynamicProvider) Watch(context.Context) <-chan struct{} {
+	return p.changes
+}
+
+func (p *dynamicProvider) set(tools ...string) {
+	p.mu.Lock()
+	p.tools = tools
+	p.mu.Unlock()
+	p.changes <- struct{}{}
+}
+
+func TestDynamicUpdateNotifiesOnce(t *testing.T) {
+	tests := []struct {
+		name   string
+		update []string
+		want   []string
+	}{
+		{"add", []string{"a", "b", "c"}, []string{"a", "b", "c"}},
+		{"remove", []string{"a"}, []string{"a"}},
+		{"add and remove", []string{"b", "c"}, []string{"b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &dynamicProvider{tools: []string{"a", "b"}, changes: make(chan struct{})}
+			c := mcptest.NewClient(t, mcpserver.WithToolProviders([]mcptypes.ToolProvider{p}))
+
+			p.set(tt.update...)
+			c.WaitForNotification(mcp.MethodNotificationToolsListChanged)
+
+			// Notifications are delivered in order, so any further tools notification from the
+			// update arrives before the prompts notification of this later change
+			if err := c.Server().RegisterPrompt(mcptypes.PromptDefinition{
+				Name: "marker",
+				Handler: func(map[string]any) (string, mcptypes.Messages, error) {
+					return "", nil, nil
+				},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			c.WaitForNotification(mcp.MethodNotificationPromptsListChanged)
+
+			var count int
+			for _, notification := range c.Notifications() {
+				if notification.Method == mcp.MethodNotificationToolsListChanged {
+					count++
+				}
+			}
+			if count != 1 {
+				t.Errorf("received %d tools/list_changed notifications, want 1", count)
+			}
+
+			var names []string
+			for _, tool := range c.ListTools() {
+				names = append(names, tool.Name)
+			}
+			assertNames(t, "tools", names, tt.want)
+		})
+	}
+}
+
+// dynamicResources returns the resource URIs and URI templates it holds and reports each
+// change on its watch channel
+type dynamicResources struct {
+	mu        sync.Mutex
+	uris      []string
+	templates []string
+	changes   chan struct{}
+}
+
+func (p *dynamicResources) RegisterResources() []mcptypes.ResourceDefinition {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var resources []mcptypes.ResourceDefinition
+	for _, uri := range p.uris {
+		resources = append(resources, textResource(uri))
+	}
+	return resources
+}
+
+func (p *dynamicResources) RegisterResourceTemplates() []mcptypes.ResourceTemplateDefinition {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var templates []mcptypes.ResourceTemplateDefinition
+	for _, uriTemplate := range p.templates {
+		templates = append(templates, textTemplate(uriTemplate))
+	}
+	return templates
+}
+
+func (p *dynamicResources) Watch(context.Context) <-chan struct{} {
+	return p.changes
+}
+
+// textResource returns a resource whose content is its URI
+func textResource(uri string) mcptypes.ResourceDefinition {
+	return mcptypes.ResourceDefinition{
+		Name: uri,
+		URI:  uri,
+		Handler: func(uri string, _ map[string]any) (mcptypes.ResourceResponse, error) {
+			return mcptypes.ResourceResponse{URI: uri, Content: uri}, nil
+		},
+	}
+}
+
+// textTemplate returns a resource template whose content is the template and the URI read
+func textTemplate(uriTemplate string) mcptypes.ResourceTemplateDefinition {
+	return mcptypes.ResourceTemplateDefinition{
+		Name:        uriTemplate,
+		URITemplate: uriTemplate,
+		Handler: func(uri string, _ map[string]any) (mcptypes.ResourceResponse, error) {
+			return mcptypes.ResourceResponse{URI: uri, Content: uriTemplate + " " + uri}, nil
+		},
+	}
+}
+
+// countNotifications returns the number of notifications with the method received so far, after
+// waiting for a later prompts notification so that all notifications of earlier changes arrived
+func countNotifications(t *testing.T, c *mcptest.Client, method string) int {
+	t.Helper()
+	if err := c.Server().RegisterPrompt(mcptypes.PromptDefinition{
+		Name: "marker",
+		Handler: func(map[string]any) (string, mcptypes.Messages, error) {
+			return "", nil, nil
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	c.WaitForNotification(mcp.MethodNotificationPromptsListChanged)
+
+	var count int
+	for _, notification := range c.Notifications() {
+		if notification.Method == method {
+			count++
+		}
+	}
+	return count
+}
+
+func TestResourceProviderUpdateNotifiesOnce(t *testing.T) {
+	p := &dynamicResources{
+		uris:      []string{"res://a"},
+		templates: []string{"tpl://a/{id}"},
+		changes:   make(chan struct{}),
+	}
+	c := mcptest.NewClient(t, mcpserver.WithResourceProviders([]mcptypes.ResourceProvider{p}))
+
+	p.mu.Lock()
+	p.uris = []string{"res://b"}
+	p.templates = []string{"tpl://b/{id}"}
+	p.mu.Unlock()
+	p.changes <- struct{}{}
+	c.WaitForNotification(mcp.MethodNotificationResourcesListChanged)
+
+	if count := countNotifications(t, c, mcp.MethodNotificationResourcesListChanged); count != 1 {
+		t.Errorf("received %d resources/list_changed notifications, want 1", count)
+	}
+	if resources := c.ListResources(); len(resources) != 1 || resources[0].URI != "res://b" {
+		t.Errorf("resources = %v, want res://b", resources)
+	}
+	if templates := c.ListResourceTemplates(); len(templates) != 1 || templates[0].URITemplate.Raw() != "tpl://b/{id}" {
+		t.Errorf("resource templates = %v, want tpl://b/{id}", templates)
+	}
+}
+
+func TestRegistrationDuringCalls(t *testing.T) {
+	c := mcptest.NewClient(t,
+		mcpserver.WithToolProviders([]mcptypes.ToolProvider{listTools{{Name: "a", Handler: echoArguments}}}),
+		mcpserver.WithResourceProviders([]mcptypes.ResourceProvider{&dynamicResources{
+			uris:      []string{"res://a"},
+			templates: []string{"tpl://a/{id}"},
+		}}),
+	)
+	srv := c.Server()
+
+	// Another goroutine keeps registering and removing different entries
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			_ = srv.RegisterTool(mcptypes.ToolDefinition{Name: "b", Handler: echoArguments})
+			_ = srv.RegisterResource(textResource("res://b"))
+			_ = srv.RegisterResourceTemplate(textTemplate("tpl://b/{id}"))
+			srv.UnregisterTool("b")
+			srv.UnregisterResource("res://b")
+			srv.UnregisterResourceTemplate("tpl://b/{id}")
+		}
+	}()
+
+	for i := range 1000 {
+		if _, err := c.CallToolErr("a", nil); err != nil {
+			t.Fatalf("call %d of tool a failed: %v", i, err)
+		}
+		c.ReadResource("res://a")
+		c.ReadResource("tpl://a/1")
+	}
+	close(done)
+	wg.Wait()
+
+	// The removed template is neither listed nor readable
+	if templates := c.ListResourceTemplates(); len(templates) != 1 || templates[0].URITemplate.Raw() != "tpl://a/{id}" {
+		t.Errorf("resource templates = %v, want tpl://a/{id}", templates)
+	}
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = "tpl://b/1"
+	if _, err := c.MCPClient().ReadResource(context.Background(), request); err == nil {
+		t.Error("read of a removed resource template succeeded")
+	}
+}
+
+func TestPromptRegistrationDuringCalls(t *testing.T) {
+	prompt := func(name string) mcptypes.PromptDefinition {
+		return mcptypes.PromptDefinition{
+			Name: name,
+			Handler: func(map[string]any) (string, mcptypes.Messages, error) {
+				return name, mcptypes.Messages{{Role: "user", Content: name}}, nil
+			},
+		}
+	}
+	c := mcptest.NewClient(t)
+	srv := c.Server()
+	if err := srv.RegisterPrompt(prompt("a")); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			_ = srv.RegisterPrompt(prompt("b"))
+			srv.UnregisterPrompt("b")
+		}
+	}()
+
+	for range 1000 {
+		if result := c.GetPrompt("a", nil); result.Description != "a" {
+			t.Fatalf("prompt a returned %q", result.Description)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
@@ -11,8 +11,26 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// hookAfterInitialize advertises the listChanged capabilities. mcp-go is configured without
+// them, because it would notify clients on every add and delete; the registry sends one
+// notification per change instead.
+//
+//goland:noinspection GoUnusedParameter
+func (m *MCPServer) hookAfterInitialize(ctx context.Context, id any, request *mcp.InitializeRequest, result *mcp.InitializeResult) {
+	if result.Capabilities.Tools != nil {
+		result.Capabilities.Tools.ListChanged = true
+	}
+	if result.Capabilities.Resources != nil {
+		result.Capabilities.Resources.ListChanged = true
+	}
+	if result.Capabilities.Prompts != nil {
+		result.Capabilities.Prompts.ListChanged = true
+	}
+}
+
 //goland:noinspection GoUnusedParameter
 func (m *MCPServer) hookAfterListPrompts(ctx context.Context, id any, request *mcp.ListPromptsRequest, result *mcp.ListPromptsResult) {
+	result.Prompts = m.allowedPrompts(ctx, result.Prompts)
 	if m.debug {
 		m.logger.Debugf("%s: %v", request.Request.Method, result.Prompts)
 	} else {
@@ -22,6 +40,7 @@ func (m *MCPServer) hookAfterListPrompts(ctx context.Context, id any, request *m
 
 //goland:noinspection GoUnusedParameter
 func (m *MCPServer) hookAfterListResources(ctx context.Context, id any, request *mcp.ListResourcesRequest, result *mcp.ListResourcesResult) {
+	result.Resources = m.allowedResources(ctx, result.Resources)
 	if m.debug {
 		m.logger.Debugf("%s: %v", request.Request.Method, result.Resources)
 	} else {
@@ -31,6 +50,7 @@ func (m *MCPServer) hookAfterListResources(ctx context.Context, id any, request
 
 //goland:noinspection GoUnusedParameter
 func (m *MCPServer) hookAfterListResourceTemplates(ctx context.Context, id any, request *mcp.ListResourceTemplatesRequest, result *mcp.ListResourceTemplatesResult) {
+	result.ResourceTemplates = m.allowedResourceTemplates(ctx, result.ResourceTemplates)
 	if m.debug {
 		m.logger.Debugf("%s: %v", request.Request.Method, result.ResourceTemplates)
 	} else {
@@ -40,9 +60,27 @@ func (m *MCPServer) hookAfterListResourceTemplates(ctx context.Context, id any,
 
 //goland:noinspection GoUnusedParameter
 func (m *MCPServer) hookAfterListTools(ctx context.Context, id any, request *mcp.ListToolsRequest, result *mcp.ListToolsResult) {
+	result.Tools = m.allowedTools(ctx, result.Tools)
 	if m.debug {
 		m.logger.Debugf("%s: %v", request.Request.Method, result.Tools)
 	} else {
 		m.logger.Infof("%s: %d items returned", request.Request.Method, len(result.Tools))
 	}
 }
+
+// requestIDMetaKey is the _meta field used to carry the JSON-RPC request ID into tool handlers
+const requestIDMetaKey = "mcplaunchpad/requestId"
+
+// hookBeforeCallTool records the JSON-RPC request ID in the request metadata, since
+// mcp-go does not otherwise pass it to tool handlers
+//
+//goland:noinspection GoUnusedParameter
+func (m *MCPServer) hookBeforeCallTool(ctx context.Context, id any, request *mcp.CallToolRequest) {
+	if request.Params.Meta == nil {
+		request.Params.Meta = &mcp.Meta{}
+	}
+	if request.Params.Meta.AdditionalFields == nil {
+		request.Params.Meta.AdditionalFields = make(map[string]any)
+	}
+	request.Params.Meta.AdditionalFields[requestIDMetaKey] = requestIDString(id)
+}
@@ -0,0 +1,302 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// Listener configures one transport served by an MCPServer. All listeners of a server
+// share the same tools, resources and prompts, but each has its own lifecycle and
+// authentication settings.
+type Listener struct {
+	Mode   TransportMode
+	Listen string // Address for SSE and HTTP listeners, or socket path for Unix listeners
+
+	network   string       // "tcp" (default) or "unix"
+	listener  net.Listener // Caller-supplied listener, used instead of binding Listen
+	fileMode  os.FileMode  // Unix socket file mode, if non-zero
+	uid, gid  int          // Unix socket owner, -1 to leave unchanged
+	validator mcptypes.BearerTokenValidator
+	noAuth    bool
+	noTLS     bool
+}
+
+// StdioListener creates a listener that communicates over stdin/stdout
+func StdioListener() *Listener {
+	return &Listener{Mode: TransportStdio}
+}
+
+// SSEListener creates a listener that serves Server-Sent Events on the given address
+func SSEListener(listen string) *Listener {
+	return &Listener{Mode: TransportSSE, Listen: listen}
+}
+
+// HTTPListener creates a listener that serves streamable HTTP on the given address
+func HTTPListener(listen string) *Listener {
+	return &Listener{Mode: TransportHTTP, Listen: listen}
+}
+
+// WebSocketListener creates a listener that serves JSON-RPC over WebSocket on the given address
+func WebSocketListener(listen string) *Listener {
+	return &Listener{Mode: TransportWebSocket, Listen: listen}
+}
+
+// UnixSSEListener creates a listener that serves Server-Sent Events on a Unix domain socket
+func UnixSSEListener(path string) *Listener {
+	return &Listener{Mode: TransportSSE, Listen: path, network: "unix", uid: -1, gid: -1}
+}
+
+// UnixHTTPListener creates a listener that serves streamable HTTP on a Unix domain socket
+func UnixHTTPListener(path string) *Listener {
+	return &Listener{Mode: TransportHTTP, Listen: path, network: "unix", uid: -1, gid: -1}
+}
+
+// UnixWebSocketListener creates a listener that serves WebSocket on a Unix domain socket
+func UnixWebSocketListener(path string) *Listener {
+	return &Listener{Mode: TransportWebSocket, Listen: path, network: "unix", uid: -1, gid: -1}
+}
+
+// SSEListenerFrom creates a listener that serves Server-Sent Events on an existing net.Listener,
+// such as one passed in by systemd socket activation (see SystemdListeners)
+func SSEListenerFrom(ln net.Listener) *Listener {
+	return &Listener{Mode: TransportSSE, Listen: ln.Addr().String(), network: ln.Addr().Network(), listener: ln}
+}
+
+// HTTPListenerFrom creates a listener that serves streamable HTTP on an existing net.Listener
+func HTTPListenerFrom(ln net.Listener) *Listener {
+	return &Listener{Mode: TransportHTTP, Listen: ln.Addr().String(), network: ln.Addr().Network(), listener: ln}
+}
+
+// WebSocketListenerFrom creates a listener that serves WebSocket on an existing net.Listener
+func WebSocketListenerFrom(ln net.Listener) *Listener {
+	return &Listener{Mode: TransportWebSocket, Listen: ln.Addr().String(), network: ln.Addr().Network(), listener: ln}
+}
+
+// WithSocketMode sets the file mode of a Unix domain socket, for example 0660
+func (l *Listener) WithSocketMode(mode os.FileMode) *Listener {
+	l.fileMode = mode
+	return l
+}
+
+// WithSocketOwner sets the owner and group of a Unix domain socket. Pass -1 to leave either unchanged.
+func (l *Listener) WithSocketOwner(uid, gid int) *Listener {
+	l.uid = uid
+	l.gid = gid
+	return l
+}
+
+// WithBearerTokenAuth sets a bearer token validator for this listener only, overriding the
+// server-wide validator
+func (l *Listener) WithBearerTokenAuth(validator mcptypes.BearerTokenValidator) *Listener {
+	l.validator = validator
+	l.noAuth = false
+	return l
+}
+
+// WithoutAuth disables authentication for this listener even if a server-wide validator is set
+func (l *Listener) WithoutAuth() *Listener {
+	l.validator = nil
+	l.noAuth = true
+	return l
+}
+
+// WithoutTLS serves this listener without TLS even if TLS is configured, for example a Unix
+// domain socket whose file mode already restricts its clients
+func (l *Listener) WithoutTLS() *Listener {
+	l.noTLS = true
+	return l
+}
+
+// String returns a description of the listener for log messages
+func (l *Listener) String() string {
+	switch l.Mode {
+	case TransportStdio:
+		return "stdio"
+	case TransportSSE:
+		return fmt.Sprintf("SSE on %s", l.address())
+	case TransportHTTP:
+		return fmt.Sprintf("HTTP on %s", l.address())
+	case TransportWebSocket:
+		return fmt.Sprintf("WebSocket on %s", l.address())
+	default:
+		return fmt.Sprintf("unknown transport %d", l.Mode)
+	}
+}
+
+// resolveTransports builds the transport list from the listener and transport options and validates it
+func (m *MCPServer) resolveTransports() error {
+	var listeners []*Listener
+	if m.transportConfigured {
+		listeners = append(listeners, &Listener{Mode: m.transportMode, Listen: m.listen})
+	}
+	listeners = append(listeners, m.extraListeners...)
+
+	if len(listeners) == 0 && len(m.customTransports) == 0 && !m.handlerOnly {
+		return fmt.Errorf("no transport mode specified; use WithTransportStdio(), WithTransportSSE(), WithTransportHTTP(), WithTransports(), WithTransport() or WithHandlerOnly()")
+	}
+
+	stdio := 0
+	addresses := make(map[string]bool)
+	for _, l := range listeners {
+		switch l.Mode {
+		case TransportStdio:
+			stdio++
+			if stdio > 1 {
+				return fmt.Errorf("only one stdio transport may be configured")
+			}
+		case TransportSSE, TransportHTTP, TransportWebSocket:
+			if l.Listen == "" && l.listener == nil {
+				return fmt.Errorf("%s listener has no listen address", l)
+			}
+			if addresses[l.address()] {
+				return fmt.Errorf("listen address %s is used by more than one transport", l.address())
+			}
+			addresses[l.address()] = true
+		default:
+			return fmt.Errorf("unknown transport mode: %d", l.Mode)
+		}
+	}
+
+	var transports []Transport
+	for _, l := range listeners {
+		transports = append(transports, m.transportFor(l))
+	}
+	for _, t := range m.customTransports {
+		if t == nil {
+			return fmt.Errorf("WithTransport() called with a nil transport")
+		}
+		transports = append(transports, t)
+	}
+
+	m.transports = transports
+	return nil
+}
+
+// validatorFor returns the bearer token validator that applies to a listener, if any
+func (m *MCPServer) validatorFor(l *Listener) mcptypes.BearerTokenValidator {
+	if l.noAuth {
+		return nil
+	}
+	if l.validator != nil {
+		return l.validator
+	}
+	return m.bearerTokenValidator
+}
+
+// newHTTPTransport creates an SSE, streamable HTTP or WebSocket handler serving its endpoints
+// under the configured base path, wrapped with authentication if a validator is given (which
+// also publishes the protected resource metadata, if configured). It
+// returns the handler and a function that closes open sessions and shuts httpSrv down; httpSrv
+// may be an unstarted placeholder when the caller serves the handler itself.
+func (m *MCPServer) newHTTPTransport(mode TransportMode, validator mcptypes.BearerTokenValidator, httpSrv *http.Server) (http.Handler, func(ctx context.Context) error) {
+	var handler http.Handler
+	var shutdown func(ctx context.Context) error
+	var endpoint string
+	switch mode {
+	case TransportSSE:
+		endpoint = m.SSEEndpoint()
+		sseServer := server.NewSSEServer(m.srv,
+			server.WithHTTPServer(httpSrv),
+			server.WithStaticBasePath(m.basePath))
+		handler = sseServer
+		shutdown = sseServer.Shutdown
+	case TransportWebSocket:
+		endpoint = m.WebSocketEndpoint()
+		wsTransport := newWebSocketTransport(m)
+		handler = wsTransport
+		shutdown = func(ctx context.Context) error {
+			// Hijacked connections are not closed by http.Server.Shutdown
+			return errors.Join(wsTransport.shutdown(ctx), httpSrv.Shutdown(ctx))
+		}
+	default:
+		endpoint = m.StreamableEndpoint()
+		httpServer := server.NewStreamableHTTPServer(m.srv, server.WithStreamableHTTPServer(httpSrv))
+		mux := http.NewServeMux()
+		mux.Handle(m.StreamableEndpoint(), httpServer)
+		handler = mux
+		shutdown = httpServer.Shutdown
+	}
+
+	// Wrap with authentication if configured
+	if validator != nil {
+		handler = wrapHTTPHandlerWithAuth(handler, validator, m.logger, m.newProtectedResource(endpoint), m.requiredScopes)
+	}
+
+	// Add the verified client certificate identity, if any, to the auth context
+	handler = &clientCertHTTPMiddleware{handler: handler}
+
+	return handler, shutdown
+}
+
+// mountableHandler creates a handler for the caller to serve, shut down by Stop()
+func (m *MCPServer) mountableHandler(mode TransportMode) http.Handler {
+	handler, shutdown := m.newHTTPTransport(mode, m.bearerTokenValidator, &http.Server{})
+
+	m.runningMu.Lock()
+	m.mounted = append(m.mounted, shutdown)
+	m.runningMu.Unlock()
+
+	return handler
+}
+
+// Handler returns an http.Handler serving the streamable HTTP endpoint, for mounting into an
+// existing http.ServeMux or router. It is equivalent to StreamableHandler.
+func (m *MCPServer) Handler() http.Handler {
+	return m.StreamableHandler()
+}
+
+// StreamableHandler returns an http.Handler serving the streamable HTTP endpoint at
+// StreamableEndpoint(), with the server-wide bearer token authentication applied. The handler
+// matches absolute request paths, so mount it at "/" or at the base path (with a trailing
+// slash) without stripping the prefix. Stop() shuts the transport down; the caller owns the
+// http.Server.
+func (m *MCPServer) StreamableHandler() http.Handler {
+	return m.mountableHandler(TransportHTTP)
+}
+
+// SSEHandler returns an http.Handler serving the SSE endpoints at SSEEndpoint() and
+// MessageEndpoint(), with the server-wide bearer token authentication applied. Mount it
+// as described for StreamableHandler.
+func (m *MCPServer) SSEHandler() http.Handler {
+	return m.mountableHandler(TransportSSE)
+}
+
+// WebSocketHandler returns an http.Handler serving WebSocket connections at WebSocketEndpoint(),
+// with the server-wide bearer token authentication applied. Mount it as described for
+// StreamableHandler.
+func (m *MCPServer) WebSocketHandler() http.Handler {
+	return m.mountableHandler(TransportWebSocket)
+}
+
+// StreamableEndpoint returns the path of the streamable HTTP endpoint
+func (m *MCPServer) StreamableEndpoint() string {
+	return m.basePath + "/mcp"
+}
+
+// SSEEndpoint returns the path of the SSE stream endpoint
+func (m *MCPServer) SSEEndpoint() string {
+	return m.basePath + "/sse"
+}
+
+// MessageEndpoint returns the path of the SSE message endpoint
+func (m *MCPServer) MessageEndpoint() string {
+	return m.basePath + "/message"
+}
+
+// WebSocketEndpoint returns the path of the WebSocket endpoint
+func (m *MCPServer) WebSocketEndpoint() string {
+	return m.basePath + "/ws"
+}
@@ -7,6 +7,8 @@ package mcpserver
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -22,16 +24,27 @@ type MCPServer struct {
 	listen              string
 	transportMode       TransportMode
 	transportConfigured bool
-
-	// mcp-go server and transports
-	srv        *server.MCPServer
-	sseServer  *server.SSEServer
-	httpServer *server.StreamableHTTPServer
+	extraListeners      []*Listener
+	customTransports    []Transport
+	transports          []Transport
+	handlerOnly         bool
+	basePath            string
+
+	// mcp-go server and running transports
+	srv       *server.MCPServer
+	runningMu sync.Mutex
+	started   []Transport
+	mounted   []func(ctx context.Context) error // Shutdown of handlers returned by Handler() etc.
 
 	// Lifecycle management
-	ctx    context.Context
-	cancel context.CancelFunc
-	wg     sync.WaitGroup
+	ctx             context.Context
+	cancel          context.CancelFunc
+	wg              sync.WaitGroup // provider watchers
+	serveWg         sync.WaitGroup // network listeners
+	shutdownTimeout time.Duration
+	errCh           chan error
+	errMu           sync.Mutex
+	serveErrs       []error
 
 	// Configuration
 	logger  mcptypes.Logger
@@ -43,9 +56,54 @@ type MCPServer struct {
 	toolProviders     []mcptypes.ToolProvider
 	resourceProviders []mcptypes.ResourceProvider
 	promptProviders   []mcptypes.PromptProvider
+	providers         []any // Added with WithProvider, merged into the lists above by New()
+
+	// Registered definitions, keyed by tool name, resource URI, URI template and prompt name,
+	// and the source that registered each of them
+	registryMu     sync.Mutex
+	tools          map[string]mcptypes.ToolDefinition
+	resources      map[string]mcptypes.ResourceDefinition
+	templates      map[string]mcptypes.ResourceTemplateDefinition
+	prompts        map[string]mcptypes.PromptDefinition
+	toolOwners     map[string]source
+	resourceOwners map[string]source
+	templateOwners map[string]source
+	promptOwners   map[string]source
+
+	// Names registered by each provider (by provider index), used to diff dynamic updates
+	providerMu        sync.Mutex
+	providerTools     map[int][]string
+	providerResources map[int][]string
+	providerTemplates map[int][]string
+	providerPrompts   map[int][]string
+
+	// Tool calls in progress, cancelled by notifications/cancelled
+	callsMu sync.Mutex
+	calls   map[callKey]*inFlightCall
+
+	// Argument handling
+	validateArguments bool
+	coercion          CoercionPolicy
 
 	// Authentication
 	bearerTokenValidator mcptypes.BearerTokenValidator
+	resourceMetadata     *ResourceMetadata
+	requiredScopes       []string
+	roleClaim            string
+	stdioTokenEnv        string
+
+	// WebSocket settings
+	wsPingInterval   time.Duration
+	wsMaxMessageSize int64
+	wsOrigins        []string
+	wsConcurrency    int
+
+	// TLS for SSE and HTTP listeners
+	tlsConfig       *tls.Config
+	tlsCertFile     string
+	tlsKeyFile      string
+	tlsClientCAFile string
+	certReloader    *certReloader
 
 	// Default hint values (Level 2 configuration)
 	defaultReadOnlyHint    *bool
@@ -63,8 +121,6 @@ func New(options ...Option) (*MCPServer, error) {
 		transportMode:       TransportSSE, // Default if not specified
 		transportConfigured: false,
 		srv:                 nil,
-		sseServer:           nil,
-		httpServer:          nil,
 		ctx:                 nil,
 		cancel:              nil,
 		logger:              nil,
@@ -72,6 +128,26 @@ func New(options ...Option) (*MCPServer, error) {
 		name:                "Generic-MCP",
 		version:             "0.0.1",
 		wg:                  sync.WaitGroup{},
+		shutdownTimeout:     5 * time.Second,
+		errCh:               make(chan error, 16),
+		validateArguments:   true,
+		stdioTokenEnv:       DefaultStdioTokenEnv,
+		wsPingInterval:      defaultWebSocketPingInterval,
+		wsMaxMessageSize:    defaultWebSocketMaxMessageSize,
+		wsConcurrency:       defaultWebSocketConcurrency,
+		tools:               make(map[string]mcptypes.ToolDefinition),
+		resources:           make(map[string]mcptypes.ResourceDefinition),
+		templates:           make(map[string]mcptypes.ResourceTemplateDefinition),
+		prompts:             make(map[string]mcptypes.PromptDefinition),
+		toolOwners:          make(map[string]source),
+		resourceOwners:      make(map[string]source),
+		templateOwners:      make(map[string]source),
+		promptOwners:        make(map[string]source),
+		providerTools:       make(map[int][]string),
+		providerResources:   make(map[int][]string),
+		providerTemplates:   make(map[int][]string),
+		providerPrompts:     make(map[int][]string),
+		calls:               make(map[callKey]*inFlightCall),
 		// Hint defaults are nil (will use package defaults)
 	}
 
@@ -81,13 +157,28 @@ func New(options ...Option) (*MCPServer, error) {
 	}
 
 	// Validate transport configuration
-	if !m.transportConfigured {
-		return nil, fmt.Errorf("no transport mode specified; use WithTransportStdio(), WithTransportSSE(), or WithTransportHTTP()")
+	if err := m.resolveTransports(); err != nil {
+		return nil, err
+	}
+
+	// Load TLS certificates
+	if err := m.resolveTLS(); err != nil {
+		return nil, err
+	}
+
+	// Check the protected resource metadata
+	if err := m.resolveResourceMetadata(); err != nil {
+		return nil, err
 	}
 
 	// If there is no logger, use no-op logger
 	if m.logger == nil {
-		m.logger = &noopLogger{}
+		m.logger = mcptypes.NoopLogger{}
+	}
+
+	// Add the providers of WithProvider to the provider lists
+	if err := m.resolveProviders(); err != nil {
+		return nil, err
 	}
 
 	// Create hooks
@@ -96,6 +187,9 @@ func New(options ...Option) (*MCPServer, error) {
 	hooks.AddAfterListResources(m.hookAfterListResources)
 	hooks.AddAfterListResourceTemplates(m.hookAfterListResourceTemplates)
 	hooks.AddAfterListTools(m.hookAfterListTools)
+	hooks.AddBeforeCallTool(m.hookBeforeCallTool)
+	hooks.AddOnRequestInitialization(m.hookRequestInitialization)
+	hooks.AddAfterInitialize(m.hookAfterInitialize)
 
 	// Create an MCP server using the mcp-go library
 	m.srv = server.NewMCPServer(
@@ -104,13 +198,20 @@ func New(options ...Option) (*MCPServer, error) {
 		server.WithLogging(),
 		server.WithRecovery(),
 		server.WithHooks(hooks),
+		// The registry sends the list_changed notifications itself (see hookAfterInitialize)
+		server.WithToolCapabilities(false),
+		server.WithResourceCapabilities(false, false),
+		server.WithPromptCapabilities(false),
 		withRequestLogging(m.logger), // Our custom request logging middleware
+		withBearerTokenAuth(m.logger),
 	)
 
+	// Cancel tool calls when the client asks
+	m.srv.AddNotificationHandler(cancelledNotification, m.handleCancelled)
+
 	// Register tools, resources, and prompts
 	m.AddTools()
 	m.AddResources()
-	m.AddResourceTemplates()
 	m.AddPrompts()
 
 	// Return the MCPServer instance
@@ -118,114 +219,139 @@ func New(options ...Option) (*MCPServer, error) {
 }
 
 // Start runs the MCP server.
-// For stdio mode, this blocks until EOF or signal.
-// For HTTP/SSE modes, this runs in a background goroutine.
+// Every transport is started before Start returns, so address errors are returned
+// immediately; network listeners are then served in background goroutines. If a stdio
+// listener is configured, Start blocks until EOF on stdin; otherwise it returns immediately.
 func (m *MCPServer) Start() error {
 	if m.logger == nil {
 		return fmt.Errorf("logger not set")
 	}
 
-	switch m.transportMode {
-	case TransportStdio:
-		// Stdio mode blocks until EOF
-		m.logger.Info("MCP server starting in stdio mode")
-		err := server.ServeStdio(m.srv)
-		if err != nil {
-			m.logger.Errorf("Stdio server error: %v", err)
+	// Subscribe to providers that push updates
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	m.watchProviders(m.ctx)
+	m.watchCertificate(m.ctx)
+
+	var stdio *stdioTransport
+	for _, t := range m.transports {
+		if err := t.Start(m.ctx, m.srv); err != nil {
+			err = fmt.Errorf("%s: %w", t.Name(), err)
+			m.logger.Errorf("MCP server failed to start: %v", err)
+			_ = m.Stop()
 			return err
 		}
-		m.logger.Info("Stdio server closed")
-		return nil
 
-	case TransportSSE:
-		// SSE mode runs in background
-		m.ctx, m.cancel = context.WithCancel(context.Background())
-		m.wg.Add(1)
-		go func() {
-			defer m.wg.Done()
-			m.logger.Infof("MCP server listening on %s (SSE mode)", m.listen)
-			m.sseServer = server.NewSSEServer(m.srv)
-
-			// Wrap with authentication if configured
-			if m.bearerTokenValidator != nil {
-				handler := wrapHTTPHandlerWithAuth(m.sseServer, m.bearerTokenValidator, m.logger)
-				err := startHTTPServerWithHandler(m.listen, handler)
-				_ = err
-			} else {
-				err := m.sseServer.Start(m.listen)
-				_ = err
-			}
-		}()
-		return nil
+		m.runningMu.Lock()
+		m.started = append(m.started, t)
+		m.runningMu.Unlock()
+
+		switch t := t.(type) {
+		case *stdioTransport:
+			stdio = t
+		case *networkTransport:
+			// Logged when the listener starts serving
+		default:
+			m.logger.Infof("MCP server started %s", t.Name())
+		}
+	}
 
-	case TransportHTTP:
-		// HTTP mode runs in background
-		m.ctx, m.cancel = context.WithCancel(context.Background())
-		m.wg.Add(1)
-		go func() {
-			defer m.wg.Done()
-			m.logger.Infof("MCP server listening on %s (HTTP mode)", m.listen)
-			m.httpServer = server.NewStreamableHTTPServer(m.srv)
-
-			// Wrap with authentication if configured
-			if m.bearerTokenValidator != nil {
-				handler := wrapHTTPHandlerWithAuth(m.httpServer, m.bearerTokenValidator, m.logger)
-				err := startHTTPServerWithHandler(m.listen, handler)
-				_ = err
-			} else {
-				err := m.httpServer.Start(m.listen)
-				_ = err
-			}
-		}()
+	if stdio == nil {
 		return nil
+	}
 
-	default:
-		return fmt.Errorf("unknown transport mode: %d", m.transportMode)
+	// Stdio mode blocks until EOF
+	m.logger.Info("MCP server starting in stdio mode")
+	if err := stdio.wait(); err != nil {
+		m.logger.Errorf("Stdio server error: %v", err)
+		return err
 	}
+	m.logger.Info("Stdio server closed")
+	return nil
 }
 
-// Stop signals the MCP server to shut down and waits for goroutines to exit.
-// For stdio mode, this is a no-op (use signal handling).
+// Stop signals the MCP server to shut down and waits up to the shutdown timeout
+// (see WithShutdownTimeout) for open connections to drain and goroutines to exit.
+// It returns any errors from shutting down the transports, or an error if the
+// timeout expired.
 func (m *MCPServer) Stop() error {
-	// Stdio mode doesn't need explicit stop
-	if m.transportMode == TransportStdio {
-		return nil
-	}
-
 	// Cancel context to signal shutdown
 	if m.cancel != nil {
 		m.cancel()
 	}
 
-	// Shutdown the appropriate transport
-	var transport interface {
-		Shutdown(context.Context) error
+	ctx, cancel := context.WithTimeout(context.Background(), m.shutdownTimeout)
+	defer cancel()
+
+	m.runningMu.Lock()
+	started := m.started
+	mounted := m.mounted
+	m.started = nil
+	m.mounted = nil
+	m.runningMu.Unlock()
+
+	// Shutdown each transport, most recently started first
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		if err := started[i].Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("shutting down %s: %w", started[i].Name(), err))
+		}
 	}
-
-	switch m.transportMode {
-	case TransportSSE:
-		transport = m.sseServer
-	case TransportHTTP:
-		transport = m.httpServer
+	for _, shutdown := range mounted {
+		if err := shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("shutting down mounted handler: %w", err))
+		}
 	}
 
-	if transport != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-		defer cancel()
-		_ = transport.Shutdown(ctx)
+	// Stop background providers once no more requests can reach them
+	for _, provider := range m.stoppableProviders() {
+		if err := provider.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stopping provider %T: %w", provider, err))
+		}
 	}
 
-	// Wait for server goroutine to exit with timeout
+	// Wait for server goroutines to exit within the remaining time
 	waitCh := make(chan struct{})
 	go func() {
+		m.serveWg.Wait()
 		m.wg.Wait()
 		close(waitCh)
 	}()
 
 	select {
 	case <-waitCh:
-		return nil
-	case <-time.After(1 * time.Second):
-		return nil
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("timed out after %s waiting for the server to stop", m.shutdownTimeout))
+	}
+	return errors.Join(errs...)
+}
+
+// Errors returns a channel that receives errors from listeners that fail after Start
+// has returned. Errors are dropped (but still logged and returned by Wait) if the
+// channel is not drained.
+func (m *MCPServer) Errors() <-chan error {
+	return m.errCh
+}
+
+// Wait blocks until all listeners started by Start have exited and returns the
+// errors that caused them to fail, if any. Listeners stopped by Stop are not errors.
+func (m *MCPServer) Wait() error {
+	m.serveWg.Wait()
+
+	m.errMu.Lock()
+	defer m.errMu.Unlock()
+	return errors.Join(m.serveErrs...)
+}
+
+// reportError records a serve failure for Wait and publishes it on the Errors channel
+func (m *MCPServer) reportError(err error) {
+	m.logger.Errorf("MCP server error: %v", err)
+
+	m.errMu.Lock()
+	m.serveErrs = append(m.serveErrs, err)
+	m.errMu.Unlock()
+
+	select {
+	case m.errCh <- err:
+	default:
 	}
 }
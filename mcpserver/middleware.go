@@ -27,24 +27,27 @@ func withRequestLogging(logger mcptypes.Logger) server.ServerOption {
 	})
 }
 
-// withBearerTokenAuth is a middleware function that validates bearer tokens.
-func withBearerTokenAuth(validator mcptypes.BearerTokenValidator, logger mcptypes.Logger) server.ServerOption {
+// withBearerTokenAuth is a middleware function that authenticates each tool call again with
+// the bearer token the transport received, so that a token that expires or is revoked during
+// a long-lived stdio or WebSocket session stops working. Calls on transports that did not
+// authenticate the caller, and calls whose HTTP request was just authenticated, pass unchanged.
+func withBearerTokenAuth(logger mcptypes.Logger) server.ServerOption {
 	return server.WithToolHandlerMiddleware(func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
 		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			// Call validator
-			contextData, err := validator("")
-			if err != nil {
-				logger.Warningf("Bearer token validation failed: %v", err)
-				return mcp.NewToolResultError("Authentication required"), err
+			creds, ok := credentialsFromContext(ctx)
+			if !ok || creds.request {
+				return next(ctx, request)
 			}
 
-			// Add auth context data to context
-			for key, value := range contextData {
-				ctx = context.WithValue(ctx, key, value)
+			// Validate the token again
+			info, err := creds.authenticate()
+			if err != nil {
+				logger.Warningf("Bearer token authentication failed for tool %s: %v", request.Params.Name, err)
+				return nil, err
 			}
 
-			// Call next handler with enriched context
-			return next(ctx, request)
+			// Call next handler with the current auth info
+			return next(withCredentials(ctx, creds, info), request)
 		}
 	})
 }
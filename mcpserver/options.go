@@ -5,7 +5,13 @@
 
 package mcpserver
 
-import "github.com/PivotLLM/MCPLaunchPad/mcptypes"
+import (
+	"crypto/tls"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
 
 // Option defines a function type for configuring the MCPServer.
 type Option func(*MCPServer)
@@ -20,9 +26,11 @@ const (
 	TransportSSE
 	// TransportHTTP uses plain HTTP (non-streaming)
 	TransportHTTP
+	// TransportWebSocket uses JSON-RPC messages over WebSocket
+	TransportWebSocket
 )
 
-// Transport selection options (exactly one required)
+// Transport selection options (at least one required)
 
 // WithTransportStdio configures the server to use stdio transport
 func WithTransportStdio() Option {
@@ -50,6 +58,59 @@ func WithTransportHTTP(listen string) Option {
 	}
 }
 
+// WithTransportWebSocket configures the server to use WebSocket transport
+func WithTransportWebSocket(listen string) Option {
+	return func(m *MCPServer) {
+		m.transportMode = TransportWebSocket
+		m.listen = listen
+		m.transportConfigured = true
+	}
+}
+
+// WithTransports adds listeners that serve the same tools, resources and prompts
+// alongside (or instead of) the transport selected above. At most one stdio listener
+// may be configured, e.g.:
+//
+//	WithTransports(StdioListener(), HTTPListener("localhost:8080").WithBearerTokenAuth(v))
+func WithTransports(listeners ...*Listener) Option {
+	return func(m *MCPServer) {
+		m.extraListeners = append(m.extraListeners, listeners...)
+	}
+}
+
+// WithTransport adds a custom transport, started and shut down with the built-in listeners
+func WithTransport(transport Transport) Option {
+	return func(m *MCPServer) {
+		m.customTransports = append(m.customTransports, transport)
+	}
+}
+
+// WithHandlerOnly configures the server without listeners of its own, for use with
+// Handler(), StreamableHandler() or SSEHandler() on a caller-owned http.Server
+func WithHandlerOnly() Option {
+	return func(m *MCPServer) {
+		m.handlerOnly = true
+	}
+}
+
+// WithBasePath sets the path prefix for the HTTP endpoints (e.g. "/api" serves
+// streamable HTTP at /api/mcp and SSE at /api/sse and /api/message)
+func WithBasePath(basePath string) Option {
+	return func(m *MCPServer) {
+		m.basePath = "/" + strings.Trim(basePath, "/")
+		if m.basePath == "/" {
+			m.basePath = ""
+		}
+	}
+}
+
+// WithShutdownTimeout sets how long Stop() waits for connections to drain (default: 5s)
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(m *MCPServer) {
+		m.shutdownTimeout = timeout
+	}
+}
+
 // Basic configuration options
 
 // WithLogger sets the logger for the server
@@ -103,6 +164,33 @@ func WithPromptProviders(providers []mcptypes.PromptProvider) Option {
 	}
 }
 
+// WithProvider adds a provider to the tool, resource and prompt providers, for each of the
+// interfaces it implements. It may be used more than once, together with the options above.
+// Providers implementing mcptypes.StoppableProvider, such as a gateway, are stopped by Stop().
+func WithProvider(provider any) Option {
+	return func(s *MCPServer) {
+		s.providers = append(s.providers, provider)
+	}
+}
+
+// Validation options
+
+// WithArgumentValidation enables or disables validation of tool arguments against
+// the declared parameters (enabled by default)
+func WithArgumentValidation(enabled bool) Option {
+	return func(m *MCPServer) {
+		m.validateArguments = enabled
+	}
+}
+
+// WithArgumentCoercion sets the type conversions applied to tool arguments before
+// validation (default: none). Use CoerceAll to enable every conversion.
+func WithArgumentCoercion(policy CoercionPolicy) Option {
+	return func(m *MCPServer) {
+		m.coercion = policy
+	}
+}
+
 // Authentication options
 
 // WithBearerTokenAuth enables bearer token authentication
@@ -112,6 +200,105 @@ func WithBearerTokenAuth(validator mcptypes.BearerTokenValidator) Option {
 	}
 }
 
+// WithStdioTokenEnv sets the environment variable the stdio transport reads a bearer token
+// from (default: MCP_AUTH_TOKEN). The variable is removed from the environment once read, so
+// that it is not inherited by child processes.
+func WithStdioTokenEnv(name string) Option {
+	return func(m *MCPServer) {
+		m.stdioTokenEnv = name
+	}
+}
+
+// WithResourceMetadata publishes OAuth 2.0 Protected Resource Metadata (RFC 9728) at
+// /.well-known/oauth-protected-resource on listeners with bearer token authentication, and
+// points clients to it in WWW-Authenticate challenges
+func WithResourceMetadata(metadata ResourceMetadata) Option {
+	return func(m *MCPServer) {
+		m.resourceMetadata = &metadata
+	}
+}
+
+// WithRoleClaim sets the auth data key that roles are read from when checking the Roles of
+// tools, resources and prompts. It may be a dotted path such as "realm_access.roles"
+// (default: "roles" or "role").
+func WithRoleClaim(claim string) Option {
+	return func(m *MCPServer) {
+		m.roleClaim = claim
+	}
+}
+
+// WithRequiredScopes requires every bearer token to grant the given scopes, taken from the
+// "scope" or "scp" value returned by the validator. Other tokens are rejected with 403
+// insufficient_scope.
+func WithRequiredScopes(scopes ...string) Option {
+	return func(m *MCPServer) {
+		m.requiredScopes = append(m.requiredScopes, scopes...)
+	}
+}
+
+// WithWebSocketPingInterval sets how often WebSocket connections are pinged (default: 30s).
+// Connections that send nothing for two intervals are closed. Zero disables pings.
+func WithWebSocketPingInterval(interval time.Duration) Option {
+	return func(m *MCPServer) {
+		m.wsPingInterval = interval
+	}
+}
+
+// WithWebSocketMaxMessageSize sets the largest WebSocket message accepted, in bytes (default: 4 MiB).
+// Sizes of zero or less select the default; messages are never unlimited.
+func WithWebSocketMaxMessageSize(size int64) Option {
+	return func(m *MCPServer) {
+		if size <= 0 {
+			size = defaultWebSocketMaxMessageSize
+		}
+		m.wsMaxMessageSize = size
+	}
+}
+
+// WithWebSocketConcurrency sets how many requests of one WebSocket connection are handled at a
+// time (default: 16). Once the limit is reached, the connection is not read until a request
+// completes.
+func WithWebSocketConcurrency(requests int) Option {
+	return func(m *MCPServer) {
+		m.wsConcurrency = requests
+	}
+}
+
+// WithWebSocketOrigins allows browser WebSocket connections from the given origins, for example
+// "https://app.example.com", or from any origin with "*". Upgrade requests whose Origin header
+// names another site than the server's own host are rejected with 403. Requests without an
+// Origin header, which non-browser clients usually omit, are not affected.
+func WithWebSocketOrigins(origins ...string) Option {
+	return func(m *MCPServer) {
+		m.wsOrigins = append(m.wsOrigins, origins...)
+	}
+}
+
+// WithTLS serves SSE and HTTP listeners over TLS using a certificate and key in PEM files.
+// The files are checked periodically and reloaded when they change.
+func WithTLS(certFile, keyFile string) Option {
+	return func(m *MCPServer) {
+		m.tlsCertFile = certFile
+		m.tlsKeyFile = keyFile
+	}
+}
+
+// WithTLSConfig serves SSE and HTTP listeners over TLS using the given configuration.
+// WithTLS and WithClientCAs, if also set, override the certificate and client CA settings.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(m *MCPServer) {
+		m.tlsConfig = config
+	}
+}
+
+// WithClientCAs requires clients to present a certificate signed by one of the CAs in the PEM file
+// (mutual TLS). The verified certificate identity is added to the auth context.
+func WithClientCAs(caFile string) Option {
+	return func(m *MCPServer) {
+		m.tlsClientCAFile = caFile
+	}
+}
+
 // Hint default configuration options
 
 // WithDefaultReadOnlyHint sets the default ReadOnlyHint for all tools
@@ -9,67 +9,83 @@ import (
 	"context"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
 )
 
 // AddPrompts registers all prompts from prompt providers
 func (m *MCPServer) AddPrompts() {
 
 	// Iterate over prompt providers and register their prompts
-	for _, provider := range m.promptProviders {
-
-		// Call the Register function of the provider to get prompt definitions
-		promptDefinitions := provider.RegisterPrompts()
+	for i := range m.promptProviders {
+		m.syncPromptProvider(i)
+	}
+}
 
-		// Iterate over the prompt definitions and register each prompt
-		for _, prompt := range promptDefinitions {
+// newServerPrompt converts a prompt definition to an mcp-go prompt with its handler
+func (m *MCPServer) newServerPrompt(prompt mcptypes.PromptDefinition) server.ServerPrompt {
 
-			// Combine description and parameters into a slice of options
-			options := []mcp.PromptOption{
-				mcp.WithPromptDescription(prompt.Description),
-			}
+	// Combine description and parameters into a slice of options
+	options := []mcp.PromptOption{
+		mcp.WithPromptDescription(prompt.Description),
+	}
 
-			// Add parameters
-			for _, param := range prompt.Parameters {
-				argOptions := []mcp.ArgumentOption{mcp.ArgumentDescription(param.Description)}
-				if param.Required {
-					argOptions = append(argOptions, mcp.RequiredArgument())
-				}
-				options = append(options, mcp.WithArgument(param.Name, argOptions...))
-			}
+	// Add parameters
+	for _, param := range prompt.Parameters {
+		argOptions := []mcp.ArgumentOption{mcp.ArgumentDescription(param.Description)}
+		if param.Required {
+			argOptions = append(argOptions, mcp.RequiredArgument())
+		}
+		options = append(options, mcp.WithArgument(param.Name, argOptions...))
+	}
 
-			// Create the prompt with all options
-			newPrompt := mcp.NewPrompt(prompt.Name, options...)
+	// Create the prompt with all options
+	newPrompt := mcp.NewPrompt(prompt.Name, options...)
 
-			// Register the prompt with the MCP server
-			m.srv.AddPrompt(newPrompt, func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return server.ServerPrompt{
+		Prompt: newPrompt,
+		Handler: func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 
-				// Copy the MCP arguments to a map
-				args := make(map[string]any)
-				for key, value := range req.Params.Arguments {
-					args[key] = value
-				}
+			// Check the caller may use the prompt
+			if err := m.authorize(ctx, prompt.Scopes, prompt.Roles); err != nil {
+				m.logger.Warningf("Prompt '%s' denied: %v", prompt.Name, err)
+				return nil, err
+			}
 
-				// Execute the prompt handler, passing the options
-				str, messages, err := prompt.Handler(args)
-				if err != nil {
-					return nil, err
-				}
+			// Copy the MCP arguments to a map
+			args := make(map[string]any)
+			for key, value := range req.Params.Arguments {
+				args[key] = value
+			}
 
-				// Convert the results to a PromptMessage struct
-				var promptMessages []mcp.PromptMessage
-				for _, message := range messages {
+			// Execute the prompt handler, passing the options
+			var str string
+			var messages mcptypes.Messages
+			var err error
+			if prompt.HandlerContext != nil {
+				str, messages, err = prompt.HandlerContext(ctx, args)
+			} else {
+				str, messages, err = prompt.Handler(args)
+			}
+			if err != nil {
+				return nil, err
+			}
 
-					var role mcp.Role
-					role = mcp.RoleAssistant
-					if message.Role == "user" {
-						role = mcp.RoleUser
-					}
+			// Convert the results to a PromptMessage struct
+			var promptMessages []mcp.PromptMessage
+			for _, message := range messages {
 
-					promptMessages = append(promptMessages,
-						mcp.NewPromptMessage(role, mcp.NewTextContent(message.Content)))
+				var role mcp.Role
+				role = mcp.RoleAssistant
+				if message.Role == "user" {
+					role = mcp.RoleUser
 				}
-				return mcp.NewGetPromptResult(str, promptMessages), nil
-			})
-		}
+
+				promptMessages = append(promptMessages,
+					mcp.NewPromptMessage(role, mcp.NewTextContent(message.Content)))
+			}
+			return mcp.NewGetPromptResult(str, promptMessages), nil
+		},
 	}
 }
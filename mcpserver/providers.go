@@ -0,0 +1,68 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// resolveProviders adds the providers of WithProvider to the tool, resource and prompt
+// providers. The lists may share their backing arrays with the caller's slices, so they are
+// clipped to force a copy before appending.
+func (m *MCPServer) resolveProviders() error {
+	for _, provider := range m.providers {
+		added := false
+		if p, ok := provider.(mcptypes.ToolProvider); ok {
+			m.toolProviders = append(slices.Clip(m.toolProviders), p)
+			added = true
+		}
+		if p, ok := provider.(mcptypes.ResourceProvider); ok {
+			m.resourceProviders = append(slices.Clip(m.resourceProviders), p)
+			added = true
+		}
+		if p, ok := provider.(mcptypes.PromptProvider); ok {
+			m.promptProviders = append(slices.Clip(m.promptProviders), p)
+			added = true
+		}
+		if !added {
+			return fmt.Errorf("%T is not a tool, resource or prompt provider", provider)
+		}
+	}
+	return nil
+}
+
+// stoppableProviders returns each provider implementing mcptypes.StoppableProvider once, even
+// if it provides several kinds of definitions
+func (m *MCPServer) stoppableProviders() []mcptypes.StoppableProvider {
+	var candidates []any
+	for _, p := range m.toolProviders {
+		candidates = append(candidates, p)
+	}
+	for _, p := range m.resourceProviders {
+		candidates = append(candidates, p)
+	}
+	for _, p := range m.promptProviders {
+		candidates = append(candidates, p)
+	}
+
+	var stoppable []mcptypes.StoppableProvider
+	for _, candidate := range candidates {
+		p, ok := candidate.(mcptypes.StoppableProvider)
+		if !ok {
+			continue
+		}
+		// Values of types that cannot be compared are distinct providers
+		if reflect.TypeOf(p).Comparable() && slices.Contains(stoppable, p) {
+			continue
+		}
+		stoppable = append(stoppable, p)
+	}
+	return stoppable
+}
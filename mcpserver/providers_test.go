@@ -0,0 +1,95 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcpserver"
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// backgroundProvider provides tools and prompts and counts how often it is stopped
+type backgroundProvider struct {
+	stops atomic.Int32
+}
+
+func (p *backgroundProvider) RegisterTools() []mcptypes.ToolDefinition {
+	return []mcptypes.ToolDefinition{{Name: "background", Handler: echoArguments}}
+}
+
+func (p *backgroundProvider) RegisterPrompts() []mcptypes.PromptDefinition {
+	return nil
+}
+
+func (p *backgroundProvider) Stop(context.Context) error {
+	p.stops.Add(1)
+	return nil
+}
+
+func TestWithProviderKeepsCallerSlices(t *testing.T) {
+	tools := make([]mcptypes.ToolProvider, 1, 4)
+	tools[0] = listTools{}
+	prompts := make([]mcptypes.PromptProvider, 0, 4)
+
+	_, err := mcpserver.New(
+		mcpserver.WithHandlerOnly(),
+		mcpserver.WithToolProviders(tools),
+		mcpserver.WithPromptProviders(prompts),
+		mcpserver.WithProvider(&backgroundProvider{}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tools[:2][1] != nil || prompts[:1][0] != nil {
+		t.Error("WithProvider wrote into the backing array of the caller's slice")
+	}
+}
+
+func TestWithProviderRejectsNonProvider(t *testing.T) {
+	if _, err := mcpserver.New(mcpserver.WithHandlerOnly(), mcpserver.WithProvider("not a provider")); err == nil {
+		t.Fatal("accepted a value that provides nothing")
+	}
+}
+
+func TestStopStopsProviders(t *testing.T) {
+	tests := []struct {
+		name    string
+		options func(p *backgroundProvider) []mcpserver.Option
+	}{
+		{"WithProvider", func(p *backgroundProvider) []mcpserver.Option {
+			return []mcpserver.Option{mcpserver.WithProvider(p)}
+		}},
+		{"provider lists", func(p *backgroundProvider) []mcpserver.Option {
+			return []mcpserver.Option{
+				mcpserver.WithToolProviders([]mcptypes.ToolProvider{p}),
+				mcpserver.WithPromptProviders([]mcptypes.PromptProvider{p}),
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &backgroundProvider{}
+			srv, err := mcpserver.New(append([]mcpserver.Option{mcpserver.WithHandlerOnly()}, tt.options(p)...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = srv.Start(); err != nil {
+				t.Fatal(err)
+			}
+			if err = srv.Stop(); err != nil {
+				t.Fatal(err)
+			}
+			// The provider is stopped once, although it provides tools and prompts
+			if stops := p.stops.Load(); stops != 1 {
+				t.Errorf("provider stopped %d times, want 1", stops)
+			}
+		})
+	}
+}
@@ -0,0 +1,343 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// The methods in this file add, replace and remove individual tools, resources, resource
+// templates and prompts while the server is running. Each change adds and deletes only the
+// affected mcp-go entries, so requests for other entries are never disturbed, and sends the
+// matching notifications/*/list_changed notification to connected clients once. All methods
+// are safe for concurrent use, including while clients are calling tools.
+
+// source identifies what registered a definition: a provider, by its index in the provider
+// list of its kind, or a Register* call
+type source int
+
+// runtimeSource is the source of definitions added with Register*
+const runtimeSource source = -1
+
+// mayRemove reports whether src may remove a definition registered by owner. Unregister* calls
+// remove any definition by name; a provider only removes the definitions it still owns, so a
+// name taken over by a later registration survives the provider dropping it.
+func (src source) mayRemove(owner source) bool {
+	return src == runtimeSource || src == owner
+}
+
+// RegisterTool adds a tool, replacing any existing tool with the same name
+func (m *MCPServer) RegisterTool(toolDef mcptypes.ToolDefinition) error {
+	_, err := m.applyTools(runtimeSource, []mcptypes.ToolDefinition{toolDef}, nil)
+	return err
+}
+
+// UnregisterTool removes a tool by name. It returns false if the tool was not registered.
+func (m *MCPServer) UnregisterTool(name string) bool {
+	removed, _ := m.applyTools(runtimeSource, nil, []string{name})
+	return removed > 0
+}
+
+// RegisterResource adds a resource, replacing any existing resource with the same URI
+func (m *MCPServer) RegisterResource(resource mcptypes.ResourceDefinition) error {
+	_, err := m.applyResources(runtimeSource, []mcptypes.ResourceDefinition{resource}, nil)
+	return err
+}
+
+// UnregisterResource removes a resource by URI. It returns false if the resource was not registered.
+func (m *MCPServer) UnregisterResource(uri string) bool {
+	removed, _ := m.applyResources(runtimeSource, nil, []string{uri})
+	return removed > 0
+}
+
+// RegisterResourceTemplate adds a resource template, replacing any existing template with the same URI template
+func (m *MCPServer) RegisterResourceTemplate(resourceTemplate mcptypes.ResourceTemplateDefinition) error {
+	_, err := m.applyResourceTemplates(runtimeSource, []mcptypes.ResourceTemplateDefinition{resourceTemplate}, nil)
+	return err
+}
+
+// UnregisterResourceTemplate removes a resource template by URI template. It returns false if
+// the template was not registered.
+func (m *MCPServer) UnregisterResourceTemplate(uriTemplate string) bool {
+	removed, _ := m.applyResourceTemplates(runtimeSource, nil, []string{uriTemplate})
+	return removed > 0
+}
+
+// RegisterPrompt adds a prompt, replacing any existing prompt with the same name
+func (m *MCPServer) RegisterPrompt(prompt mcptypes.PromptDefinition) error {
+	_, err := m.applyPrompts(runtimeSource, []mcptypes.PromptDefinition{prompt}, nil)
+	return err
+}
+
+// UnregisterPrompt removes a prompt by name. It returns false if the prompt was not registered.
+func (m *MCPServer) UnregisterPrompt(name string) bool {
+	removed, _ := m.applyPrompts(runtimeSource, nil, []string{name})
+	return removed > 0
+}
+
+// applyTools adds or replaces and removes tools as a single change on behalf of src, and
+// returns the number of tools removed. Definitions are checked before anything is modified,
+// so an invalid definition leaves the registry untouched.
+func (m *MCPServer) applyTools(src source, add []mcptypes.ToolDefinition, remove []string) (int, error) {
+	for _, toolDef := range add {
+		if err := checkToolDefinition(toolDef); err != nil {
+			return 0, err
+		}
+	}
+
+	m.registryMu.Lock()
+	defer m.registryMu.Unlock()
+	removed := m.updateTools(src, add, remove)
+	if len(add) > 0 || removed > 0 {
+		m.srv.SendNotificationToAllClients(mcp.MethodNotificationToolsListChanged, nil)
+	}
+	return removed, nil
+}
+
+// updateTools applies a checked tool change to the registry and to mcp-go, adding or replacing
+// the new tools before deleting the removed ones. It does not notify clients. The caller must
+// hold registryMu.
+func (m *MCPServer) updateTools(src source, add []mcptypes.ToolDefinition, remove []string) int {
+	entries := make([]server.ServerTool, 0, len(add))
+	for _, toolDef := range add {
+		m.tools[toolDef.Name] = toolDef
+		m.toolOwners[toolDef.Name] = src
+		entries = append(entries, m.newServerTool(toolDef))
+		m.logger.Debugf("Registered tool '%s'", toolDef.Name)
+	}
+	var names []string
+	for _, name := range remove {
+		if owner, ok := m.toolOwners[name]; !ok || !src.mayRemove(owner) {
+			continue
+		}
+		delete(m.tools, name)
+		delete(m.toolOwners, name)
+		names = append(names, name)
+		m.logger.Debugf("Unregistered tool '%s'", name)
+	}
+
+	if len(entries) > 0 {
+		m.srv.AddTools(entries...)
+	}
+	if len(names) > 0 {
+		m.srv.DeleteTools(names...)
+	}
+	return len(names)
+}
+
+// applyResources adds or replaces and removes resources as a single change on behalf of src,
+// and returns the number of resources removed
+func (m *MCPServer) applyResources(src source, add []mcptypes.ResourceDefinition, remove []string) (int, error) {
+	for _, resource := range add {
+		if err := checkResourceDefinition(resource); err != nil {
+			return 0, err
+		}
+	}
+
+	m.registryMu.Lock()
+	defer m.registryMu.Unlock()
+	removed := m.updateResources(src, add, remove)
+	if len(add) > 0 || removed > 0 {
+		m.srv.SendNotificationToAllClients(mcp.MethodNotificationResourcesListChanged, nil)
+	}
+	return removed, nil
+}
+
+// updateResources applies a checked resource change to the registry and to mcp-go. It does
+// not notify clients. The caller must hold registryMu.
+func (m *MCPServer) updateResources(src source, add []mcptypes.ResourceDefinition, remove []string) int {
+	entries := make([]server.ServerResource, 0, len(add))
+	for _, resource := range add {
+		m.resources[resource.URI] = resource
+		m.resourceOwners[resource.URI] = src
+		entries = append(entries, m.newServerResource(resource))
+		m.logger.Debugf("Registered resource '%s'", resource.URI)
+	}
+	var uris []string
+	for _, uri := range remove {
+		if owner, ok := m.resourceOwners[uri]; !ok || !src.mayRemove(owner) {
+			continue
+		}
+		delete(m.resources, uri)
+		delete(m.resourceOwners, uri)
+		uris = append(uris, uri)
+		m.logger.Debugf("Unregistered resource '%s'", uri)
+	}
+
+	if len(entries) > 0 {
+		m.srv.AddResources(entries...)
+	}
+	if len(uris) > 0 {
+		m.srv.DeleteResources(uris...)
+	}
+	return len(uris)
+}
+
+// applyResourceTemplates adds or replaces and removes resource templates as a single change on
+// behalf of src, and returns the number of templates removed
+func (m *MCPServer) applyResourceTemplates(src source, add []mcptypes.ResourceTemplateDefinition, remove []string) (int, error) {
+	for _, resourceTemplate := range add {
+		if err := checkResourceTemplateDefinition(resourceTemplate); err != nil {
+			return 0, err
+		}
+	}
+
+	m.registryMu.Lock()
+	defer m.registryMu.Unlock()
+	removed := m.updateResourceTemplates(src, add, remove)
+	if len(add) > 0 || removed > 0 {
+		m.srv.SendNotificationToAllClients(mcp.MethodNotificationResourcesListChanged, nil)
+	}
+	return removed, nil
+}
+
+// updateResourceTemplates applies a checked resource template change to the registry and to
+// mcp-go. It does not notify clients. The caller must hold registryMu.
+func (m *MCPServer) updateResourceTemplates(src source, add []mcptypes.ResourceTemplateDefinition, remove []string) int {
+	entries := make([]server.ServerResourceTemplate, 0, len(add))
+	for _, resourceTemplate := range add {
+		m.templates[resourceTemplate.URITemplate] = resourceTemplate
+		m.templateOwners[resourceTemplate.URITemplate] = src
+		entries = append(entries, m.newServerResourceTemplate(resourceTemplate))
+		m.logger.Debugf("Registered resource template '%s'", resourceTemplate.URITemplate)
+	}
+	var removed int
+	for _, uriTemplate := range remove {
+		if owner, ok := m.templateOwners[uriTemplate]; !ok || !src.mayRemove(owner) {
+			continue
+		}
+		delete(m.templates, uriTemplate)
+		delete(m.templateOwners, uriTemplate)
+		// mcp-go cannot delete a single template, so it is replaced by one that is left out of
+		// lists and reads through to the remaining templates
+		entries = append(entries, m.removedServerResourceTemplate(uriTemplate))
+		removed++
+		m.logger.Debugf("Unregistered resource template '%s'", uriTemplate)
+	}
+
+	if len(entries) > 0 {
+		m.srv.AddResourceTemplates(entries...)
+	}
+	return removed
+}
+
+// applyPrompts adds or replaces and removes prompts as a single change on behalf of src, and
+// returns the number of prompts removed
+func (m *MCPServer) applyPrompts(src source, add []mcptypes.PromptDefinition, remove []string) (int, error) {
+	for _, prompt := range add {
+		if err := checkPromptDefinition(prompt); err != nil {
+			return 0, err
+		}
+	}
+
+	m.registryMu.Lock()
+	defer m.registryMu.Unlock()
+	removed := m.updatePrompts(src, add, remove)
+	if len(add) > 0 || removed > 0 {
+		m.srv.SendNotificationToAllClients(mcp.MethodNotificationPromptsListChanged, nil)
+	}
+	return removed, nil
+}
+
+// updatePrompts applies a checked prompt change to the registry and to mcp-go. It does not
+// notify clients. The caller must hold registryMu.
+func (m *MCPServer) updatePrompts(src source, add []mcptypes.PromptDefinition, remove []string) int {
+	entries := make([]server.ServerPrompt, 0, len(add))
+	for _, prompt := range add {
+		m.prompts[prompt.Name] = prompt
+		m.promptOwners[prompt.Name] = src
+		entries = append(entries, m.newServerPrompt(prompt))
+		m.logger.Debugf("Registered prompt '%s'", prompt.Name)
+	}
+	var names []string
+	for _, name := range remove {
+		if owner, ok := m.promptOwners[name]; !ok || !src.mayRemove(owner) {
+			continue
+		}
+		delete(m.prompts, name)
+		delete(m.promptOwners, name)
+		names = append(names, name)
+		m.logger.Debugf("Unregistered prompt '%s'", name)
+	}
+
+	if len(entries) > 0 {
+		m.srv.AddPrompts(entries...)
+	}
+	if len(names) > 0 {
+		m.srv.DeletePrompts(names...)
+	}
+	return len(names)
+}
+
+// ToolNames returns the names of all registered tools in sorted order
+func (m *MCPServer) ToolNames() []string {
+	m.registryMu.Lock()
+	defer m.registryMu.Unlock()
+
+	names := make([]string, 0, len(m.tools))
+	for name := range m.tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkToolDefinition verifies that a tool definition can be registered
+func checkToolDefinition(toolDef mcptypes.ToolDefinition) error {
+	if toolDef.Name == "" {
+		return fmt.Errorf("tool has no name")
+	}
+	if toolDef.Handler == nil && toolDef.HandlerContext == nil && toolDef.HandlerResult == nil {
+		return fmt.Errorf("tool '%s' has no handler", toolDef.Name)
+	}
+	if toolDef.RawInputSchema != nil && !json.Valid(toolDef.RawInputSchema) {
+		return fmt.Errorf("tool '%s' has an invalid input schema", toolDef.Name)
+	}
+	if toolDef.RawOutputSchema != nil && !json.Valid(toolDef.RawOutputSchema) {
+		return fmt.Errorf("tool '%s' has an invalid output schema", toolDef.Name)
+	}
+	return nil
+}
+
+// checkResourceDefinition verifies that a resource definition can be registered
+func checkResourceDefinition(resource mcptypes.ResourceDefinition) error {
+	if resource.URI == "" {
+		return fmt.Errorf("resource '%s' has no URI", resource.Name)
+	}
+	if resource.Handler == nil && resource.HandlerContext == nil {
+		return fmt.Errorf("resource '%s' has no handler", resource.URI)
+	}
+	return nil
+}
+
+// checkResourceTemplateDefinition verifies that a resource template definition can be registered
+func checkResourceTemplateDefinition(resourceTemplate mcptypes.ResourceTemplateDefinition) error {
+	if resourceTemplate.URITemplate == "" {
+		return fmt.Errorf("resource template '%s' has no URI template", resourceTemplate.Name)
+	}
+	if resourceTemplate.Handler == nil && resourceTemplate.HandlerContext == nil {
+		return fmt.Errorf("resource template '%s' has no handler", resourceTemplate.URITemplate)
+	}
+	return nil
+}
+
+// checkPromptDefinition verifies that a prompt definition can be registered
+func checkPromptDefinition(prompt mcptypes.PromptDefinition) error {
+	if prompt.Name == "" {
+		return fmt.Errorf("prompt has no name")
+	}
+	if prompt.Handler == nil && prompt.HandlerContext == nil {
+		return fmt.Errorf("prompt '%s' has no handler", prompt.Name)
+	}
+	return nil
+}
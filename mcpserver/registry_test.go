@@ -0,0 +1,107 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// listProvider returns whatever tools it currently holds
+type listProvider struct {
+	tools []mcptypes.ToolDefinition
+}
+
+func (p *listProvider) RegisterTools() []mcptypes.ToolDefinition { return p.tools }
+
+func testTool(name, text string) mcptypes.ToolDefinition {
+	return mcptypes.ToolDefinition{
+		Name:    name,
+		Handler: func(map[string]any) (string, error) { return text, nil },
+	}
+}
+
+func newRegistryServer(t *testing.T, options ...Option) *MCPServer {
+	t.Helper()
+	m, err := New(append([]Option{WithHandlerOnly()}, options...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestUnregisterReportsRemovalOnce(t *testing.T) {
+	m := newRegistryServer(t)
+	if err := m.RegisterTool(testTool("t", "")); err != nil {
+		t.Fatal(err)
+	}
+
+	var removed atomic.Int32
+	var wg sync.WaitGroup
+	for range 32 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if m.UnregisterTool("t") {
+				removed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := removed.Load(); n != 1 {
+		t.Errorf("UnregisterTool reported %d removals, want 1", n)
+	}
+	if m.UnregisterTool("t") {
+		t.Error("UnregisterTool of a missing tool returned true")
+	}
+}
+
+func TestRegistryOwnership(t *testing.T) {
+	first := &listProvider{tools: []mcptypes.ToolDefinition{testTool("shared", "first"), testTool("own", "first")}}
+	second := &listProvider{}
+	m := newRegistryServer(t, WithToolProviders([]mcptypes.ToolProvider{first, second}))
+
+	// A later registration takes the name over from the provider
+	if err := m.RegisterTool(testTool("own", "runtime")); err != nil {
+		t.Fatal(err)
+	}
+	second.tools = []mcptypes.ToolDefinition{testTool("shared", "second")}
+	m.syncToolProvider(1)
+
+	// The first provider drops both names, but owns neither of them any more
+	first.tools = nil
+	m.syncToolProvider(0)
+
+	tests := []struct {
+		name  string
+		owner source
+	}{
+		{"shared", 1},
+		{"own", runtimeSource},
+	}
+	for _, tt := range tests {
+		if owner, ok := m.toolOwners[tt.name]; !ok || owner != tt.owner {
+			t.Errorf("owner of %s = %d (registered %v), want %d", tt.name, owner, ok, tt.owner)
+		}
+		if _, ok := m.tools[tt.name]; !ok {
+			t.Errorf("%s was removed", tt.name)
+		}
+	}
+
+	// The owner removes its own names, and Unregister removes a name whatever its source
+	second.tools = nil
+	m.syncToolProvider(1)
+	if !m.UnregisterTool("own") {
+		t.Error("UnregisterTool did not remove a runtime tool")
+	}
+	if names := m.ToolNames(); len(names) != 0 {
+		t.Errorf("tools left after removal: %v", names)
+	}
+}
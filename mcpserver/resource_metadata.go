@@ -0,0 +1,133 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// wellKnownResourcePath is where OAuth 2.0 Protected Resource Metadata is published (RFC 9728)
+const wellKnownResourcePath = "/.well-known/oauth-protected-resource"
+
+// ResourceMetadata describes the server as an OAuth 2.0 protected resource (RFC 9728), so
+// that MCP clients can discover which authorization servers issue tokens for it
+type ResourceMetadata struct {
+	// Resource is the URL clients use for the server, e.g. https://mcp.example.com/mcp. It is
+	// required, since the Host header of a request cannot be trusted. If it has no path, the
+	// endpoint of each transport is appended, e.g. https://mcp.example.com becomes
+	// https://mcp.example.com/mcp for the streamable HTTP endpoint.
+	Resource string `json:"resource"`
+
+	AuthorizationServers   []string `json:"authorization_servers,omitempty"` // Issuer URLs
+	ScopesSupported        []string `json:"scopes_supported,omitempty"`
+	BearerMethodsSupported []string `json:"bearer_methods_supported,omitempty"` // Default: ["header"]
+	ResourceName           string   `json:"resource_name,omitempty"`
+	ResourceDocumentation  string   `json:"resource_documentation,omitempty"`
+}
+
+// protectedResource serves the metadata document for one transport endpoint and builds the
+// URLs used in WWW-Authenticate challenges
+type protectedResource struct {
+	metadata    ResourceMetadata // Resource holds the identifier of the endpoint
+	path        string           // Path of the resource, without a trailing slash
+	metadataURL string           // URL of the metadata document
+}
+
+// resolveResourceMetadata checks the resource metadata option
+func (m *MCPServer) resolveResourceMetadata() error {
+	if m.resourceMetadata == nil {
+		return nil
+	}
+	if m.resourceMetadata.Resource == "" {
+		return fmt.Errorf("resource metadata requires the Resource URL clients use for the server")
+	}
+	resource, err := url.Parse(m.resourceMetadata.Resource)
+	if err != nil || (resource.Scheme != "https" && resource.Scheme != "http") || resource.Host == "" {
+		return fmt.Errorf("invalid resource URL %q: must be an absolute http or https URL", m.resourceMetadata.Resource)
+	}
+	if resource.RawQuery != "" || resource.Fragment != "" {
+		return fmt.Errorf("invalid resource URL %q: must not have a query or fragment", m.resourceMetadata.Resource)
+	}
+	return nil
+}
+
+// newProtectedResource returns the protected resource for an endpoint, or nil if no
+// metadata is configured
+func (m *MCPServer) newProtectedResource(endpoint string) *protectedResource {
+	if m.resourceMetadata == nil {
+		return nil
+	}
+	metadata := *m.resourceMetadata
+	if len(metadata.BearerMethodsSupported) == 0 {
+		metadata.BearerMethodsSupported = []string{"header"}
+	}
+
+	// Checked by resolveResourceMetadata
+	resource, _ := url.Parse(metadata.Resource)
+	if resource.Path == "" || resource.Path == "/" {
+		resource.Path = endpoint
+		metadata.Resource = resource.String()
+	}
+	path := strings.TrimSuffix(resource.Path, "/")
+
+	// RFC 9728 places the document at the well-known path followed by the path of the resource
+	return &protectedResource{
+		metadata:    metadata,
+		path:        path,
+		metadataURL: resource.Scheme + "://" + resource.Host + wellKnownResourcePath + path,
+	}
+}
+
+// isMetadataPath reports whether a request path is one the metadata document is served at:
+// the bare well-known path, or the well-known path followed by the resource path
+func (p *protectedResource) isMetadataPath(path string) bool {
+	if path == wellKnownResourcePath {
+		return true
+	}
+	suffix, ok := strings.CutPrefix(path, wellKnownResourcePath)
+	return ok && suffix == p.path
+}
+
+// ServeHTTP serves the metadata document. It is public and may be fetched by browser-based
+// clients, so CORS is allowed from any origin.
+func (p *protectedResource) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodOptions:
+		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	default:
+		w.Header().Set("Allow", "GET, HEAD, OPTIONS")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "max-age=3600")
+	_ = json.NewEncoder(w).Encode(p.metadata)
+}
+
+// resourceMetadataHTTPMiddleware serves the metadata document ahead of authentication and
+// passes every other request on
+type resourceMetadataHTTPMiddleware struct {
+	handler  http.Handler
+	resource *protectedResource
+}
+
+// ServeHTTP implements http.Handler
+func (m *resourceMetadataHTTPMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if m.resource.isMetadataPath(r.URL.Path) {
+		m.resource.ServeHTTP(w, r)
+		return
+	}
+	m.handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,146 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// scopedValidator accepts "read" with the mcp:read scope and "none" without scopes
+func scopedValidator(token string) (map[string]any, error) {
+	switch token {
+	case "read":
+		return map[string]any{"sub": "alice", "scope": "mcp:read"}, nil
+	case "none":
+		return map[string]any{"sub": "bob"}, nil
+	default:
+		return nil, fmt.Errorf("unknown token")
+	}
+}
+
+// newMetadataServer serves the streamable HTTP handler of a server with resource metadata
+func newMetadataServer(t *testing.T, resource string) *httptest.Server {
+	t.Helper()
+	m := newRegistryServer(t,
+		WithBearerTokenAuth(scopedValidator),
+		WithRequiredScopes("mcp:read"),
+		WithResourceMetadata(ResourceMetadata{
+			Resource:             resource,
+			AuthorizationServers: []string{"https://auth.example.com"},
+		}))
+	ts := httptest.NewServer(m.StreamableHandler())
+	t.Cleanup(func() {
+		_ = m.Stop()
+		ts.Close()
+	})
+	return ts
+}
+
+func TestResourceMetadataDocument(t *testing.T) {
+	tests := []struct {
+		name         string
+		resource     string
+		path         string
+		wantResource string
+	}{
+		{"bare well-known path", "https://mcp.example.com/mcp", "/.well-known/oauth-protected-resource", "https://mcp.example.com/mcp"},
+		{"resource path", "https://mcp.example.com/mcp", "/.well-known/oauth-protected-resource/mcp", "https://mcp.example.com/mcp"},
+		{"proxied path", "https://example.com/tools/mcp/", "/.well-known/oauth-protected-resource/tools/mcp", "https://example.com/tools/mcp/"},
+		{"endpoint appended", "https://mcp.example.com", "/.well-known/oauth-protected-resource/mcp", "https://mcp.example.com/mcp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newMetadataServer(t, tt.resource)
+
+			// A spoofed Host header does not change the resource identifier
+			req, _ := http.NewRequest(http.MethodGet, ts.URL+tt.path, nil)
+			req.Host = "evil.example.net"
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("status = %d, want 200", resp.StatusCode)
+			}
+
+			var metadata ResourceMetadata
+			if err = json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+				t.Fatal(err)
+			}
+			if metadata.Resource != tt.wantResource {
+				t.Errorf("resource = %q, want %q", metadata.Resource, tt.wantResource)
+			}
+			if len(metadata.AuthorizationServers) != 1 || metadata.BearerMethodsSupported[0] != "header" {
+				t.Errorf("metadata = %+v", metadata)
+			}
+		})
+	}
+
+	// Paths of other resources are passed on to authentication
+	ts := newMetadataServer(t, "https://mcp.example.com/mcp")
+	resp, err := http.Get(ts.URL + "/.well-known/oauth-protected-resource/other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status for another resource = %d, want 401", resp.StatusCode)
+	}
+}
+
+func TestBearerChallenges(t *testing.T) {
+	const metadataParam = `resource_metadata="https://mcp.example.com/.well-known/oauth-protected-resource/mcp"`
+	tests := []struct {
+		name          string
+		authorization string
+		status        int
+		challenge     string
+	}{
+		{"no credentials", "", http.StatusUnauthorized, `Bearer scope="mcp:read", ` + metadataParam},
+		{"invalid token", "Bearer nope", http.StatusUnauthorized, `Bearer error="invalid_token", error_description="Invalid token", scope="mcp:read", ` + metadataParam},
+		{"not a bearer token", "Basic YWxpY2U6c2VjcmV0", http.StatusBadRequest, `Bearer error="invalid_request", error_description="Invalid Authorization format - expected Bearer token", scope="mcp:read", ` + metadataParam},
+		{"insufficient scope", "Bearer none", http.StatusForbidden, `Bearer error="insufficient_scope", error_description="Insufficient scope", scope="mcp:read", ` + metadataParam},
+	}
+
+	ts := newMetadataServer(t, "https://mcp.example.com/mcp")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, ts.URL+"/mcp", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+			req.Host = "evil.example.net"
+			req.Header.Set("Content-Type", "application/json")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = resp.Body.Close()
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if got := resp.Header.Get("WWW-Authenticate"); got != tt.challenge {
+				t.Errorf("WWW-Authenticate = %s\nwant %s", got, tt.challenge)
+			}
+		})
+	}
+}
+
+func TestResourceMetadataRequiresResource(t *testing.T) {
+	for _, resource := range []string{"", "/mcp", "ftp://mcp.example.com/mcp", "https://mcp.example.com/mcp?x=1"} {
+		_, err := New(WithHandlerOnly(), WithResourceMetadata(ResourceMetadata{Resource: resource}))
+		if err == nil {
+			t.Errorf("resource %q accepted", resource)
+		}
+	}
+}
@@ -7,96 +7,125 @@ package mcpserver
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
 )
 
-// AddResources registers all resources from resource providers
+// AddResources registers all resources and resource templates from resource providers
 func (m *MCPServer) AddResources() {
 
-	// Iterate over resource providers and register their resources
-	for _, provider := range m.resourceProviders {
-
-		// Call the Register function of the provider to get resource definitions
-		resourceDefinitions := provider.RegisterResources()
-
-		// Iterate over the resource definitions and register each resource
-		for _, resource := range resourceDefinitions {
-
-			newResource := mcp.NewResource(
-				resource.URI,
-				resource.Name,
-				mcp.WithResourceDescription(resource.Description),
-				mcp.WithMIMEType(resource.MIMEType),
-			)
-
-			// Add resource with its handler
-			m.srv.AddResource(newResource,
-				func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-
-					// Copy the MCP arguments to a map
-					options := request.Params.Arguments
-					if options == nil {
-						options = make(map[string]any)
-					}
-
-					// Execute the resource handler, passing the options
-					resp, err := resource.Handler(request.Params.URI, options)
-					if err != nil {
-						return nil, err
-					}
-
-					return []mcp.ResourceContents{
-						mcp.TextResourceContents{
-							URI:      resp.URI,
-							MIMEType: resp.MIMEType,
-							Text:     resp.Content}}, nil
-				},
-			)
-		}
+	// Iterate over resource providers and register their resources and templates
+	for i := range m.resourceProviders {
+		m.syncResourceProvider(i)
+	}
+}
+
+// AddResourceTemplates registers all resource templates from resource providers.
+//
+// Deprecated: AddResources registers templates as well; this is now a no-op.
+func (m *MCPServer) AddResourceTemplates() {}
+
+// newServerResource converts a resource definition to an mcp-go resource with its handler
+func (m *MCPServer) newServerResource(resource mcptypes.ResourceDefinition) server.ServerResource {
+
+	newResource := mcp.NewResource(
+		resource.URI,
+		resource.Name,
+		mcp.WithResourceDescription(resource.Description),
+		mcp.WithMIMEType(resource.MIMEType),
+	)
+
+	return server.ServerResource{
+		Resource: newResource,
+		Handler: func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			if err := m.authorize(ctx, resource.Scopes, resource.Roles); err != nil {
+				m.logger.Warningf("Resource '%s' denied: %v", resource.URI, err)
+				return nil, err
+			}
+			return readResource(ctx, resource.Handler, resource.HandlerContext, request)
+		},
 	}
 }
 
-// AddResourceTemplates registers all resource templates from resource providers
-func (m *MCPServer) AddResourceTemplates() {
-
-	// Iterate over resource providers and register their templates
-	for _, provider := range m.resourceProviders {
-
-		// Call the Register function of the provider to get resource template definitions
-		resourceTemplates := provider.RegisterResourceTemplates()
-
-		// Iterate over the resource template definitions and register each template
-		for _, resourceTemplate := range resourceTemplates {
-
-			template := mcp.NewResourceTemplate(
-				resourceTemplate.URITemplate,
-				resourceTemplate.Name,
-				mcp.WithTemplateDescription(resourceTemplate.Description),
-				mcp.WithTemplateMIMEType(resourceTemplate.MIMEType))
-
-			// Add resource template with its handler
-			m.srv.AddResourceTemplate(template,
-				func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-					// Copy the MCP arguments to a map
-					options := request.Params.Arguments
-					if options == nil {
-						options = make(map[string]any)
-					}
-
-					// Execute the resource template handler, passing the options
-					resp, err := resourceTemplate.Handler(request.Params.URI, options)
-					if err != nil {
-						return nil, err
-					}
-
-					return []mcp.ResourceContents{
-						mcp.TextResourceContents{
-							URI:      resp.URI,
-							MIMEType: resp.MIMEType,
-							Text:     resp.Content}}, nil
-				},
-			)
-		}
+// newServerResourceTemplate converts a resource template definition to an mcp-go template with its handler
+func (m *MCPServer) newServerResourceTemplate(resourceTemplate mcptypes.ResourceTemplateDefinition) server.ServerResourceTemplate {
+
+	template := mcp.NewResourceTemplate(
+		resourceTemplate.URITemplate,
+		resourceTemplate.Name,
+		mcp.WithTemplateDescription(resourceTemplate.Description),
+		mcp.WithTemplateMIMEType(resourceTemplate.MIMEType))
+
+	return server.ServerResourceTemplate{
+		Template: template,
+		Handler: func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			if err := m.authorize(ctx, resourceTemplate.Scopes, resourceTemplate.Roles); err != nil {
+				m.logger.Warningf("Resource '%s' denied: %v", request.Params.URI, err)
+				return nil, err
+			}
+			return readResource(ctx, resourceTemplate.Handler, resourceTemplate.HandlerContext, request)
+		},
 	}
 }
+
+// removedServerResourceTemplate returns the mcp-go template that stands in for a removed
+// template. Reads it matches are passed to a remaining template that matches the URI, if any.
+func (m *MCPServer) removedServerResourceTemplate(uriTemplate string) server.ServerResourceTemplate {
+	return server.ServerResourceTemplate{
+		Template: mcp.NewResourceTemplate(uriTemplate, ""),
+		Handler: func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			m.registryMu.Lock()
+			var entry *server.ServerResourceTemplate
+			for _, resourceTemplate := range m.templates {
+				candidate := m.newServerResourceTemplate(resourceTemplate)
+				if candidate.Template.URITemplate.Regexp().MatchString(request.Params.URI) {
+					entry = &candidate
+					break
+				}
+			}
+			m.registryMu.Unlock()
+			if entry == nil {
+				return nil, fmt.Errorf("handler not found for resource URI '%s': %w", request.Params.URI, server.ErrResourceNotFound)
+			}
+
+			request.Params.Arguments = make(map[string]any)
+			for name, value := range entry.Template.URITemplate.Match(request.Params.URI) {
+				request.Params.Arguments[name] = value.V
+			}
+			return entry.Handler(ctx, request)
+		},
+	}
+}
+
+// readResource executes the context-aware resource handler if set, or else the plain one,
+// and converts its response
+func readResource(ctx context.Context, handler mcptypes.ResourceHandler, handlerContext mcptypes.ResourceHandlerContext, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+
+	// Copy the MCP arguments to a map
+	options := request.Params.Arguments
+	if options == nil {
+		options = make(map[string]any)
+	}
+
+	// Execute the resource handler, passing the options
+	var resp mcptypes.ResourceResponse
+	var err error
+	if handlerContext != nil {
+		resp, err = handlerContext(ctx, request.Params.URI, options)
+	} else {
+		resp, err = handler(request.Params.URI, options)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      resp.URI,
+			MIMEType: resp.MIMEType,
+			Text:     resp.Content}}, nil
+}
@@ -0,0 +1,104 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// toCallToolResult converts an mcptypes.ToolResult to the mcp-go result type
+func toCallToolResult(result *mcptypes.ToolResult) (*mcp.CallToolResult, error) {
+	callResult := &mcp.CallToolResult{
+		Content:           []mcp.Content{},
+		StructuredContent: result.StructuredContent,
+		IsError:           result.IsError,
+	}
+
+	for i, content := range result.Content {
+		converted, err := toMCPContent(content)
+		if err != nil {
+			return nil, fmt.Errorf("content block %d: %w", i, err)
+		}
+		callResult.Content = append(callResult.Content, converted)
+	}
+
+	// Structured results should also carry an equivalent text block for older clients
+	if len(callResult.Content) == 0 && result.StructuredContent != nil {
+		data, err := json.Marshal(result.StructuredContent)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal structured content: %w", err)
+		}
+		callResult.Content = append(callResult.Content, mcp.NewTextContent(string(data)))
+	}
+
+	return callResult, nil
+}
+
+// toMCPContent converts a single mcptypes.Content block to the matching mcp-go content type
+func toMCPContent(content mcptypes.Content) (mcp.Content, error) {
+	switch content.Type {
+	case mcptypes.ContentTypeText, "":
+		return mcp.NewTextContent(content.Text), nil
+
+	case mcptypes.ContentTypeImage:
+		return mcp.NewImageContent(base64.StdEncoding.EncodeToString(content.Data), content.MIMEType), nil
+
+	case mcptypes.ContentTypeAudio:
+		return mcp.NewAudioContent(base64.StdEncoding.EncodeToString(content.Data), content.MIMEType), nil
+
+	case mcptypes.ContentTypeResource:
+		if content.Data != nil {
+			return mcp.NewEmbeddedResource(mcp.BlobResourceContents{
+				URI:      content.URI,
+				MIMEType: content.MIMEType,
+				Blob:     base64.StdEncoding.EncodeToString(content.Data),
+			}), nil
+		}
+		return mcp.NewEmbeddedResource(mcp.TextResourceContents{
+			URI:      content.URI,
+			MIMEType: content.MIMEType,
+			Text:     content.Text,
+		}), nil
+
+	case mcptypes.ContentTypeResourceLink:
+		return mcp.NewResourceLink(content.URI, content.Name, content.Description, content.MIMEType), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported content type '%s'", content.Type)
+	}
+}
+
+// validateStructuredContent checks a result's structured content against the declared output schema
+func validateStructuredContent(schema []*mcptypes.Parameter, result *mcptypes.ToolResult) error {
+	if result == nil || result.StructuredContent == nil {
+		if len(schema) > 0 && result != nil && !result.IsError {
+			return fmt.Errorf("tool declares an output schema but returned no structured content")
+		}
+		return nil
+	}
+
+	// Normalize the value (which may be a struct) to its JSON representation
+	data, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		return fmt.Errorf("unable to marshal structured content: %w", err)
+	}
+	var value any
+	if err = json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("unable to decode structured content: %w", err)
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Errorf("structured content must be a JSON object")
+	}
+
+	return validateArguments(schema, object)
+}
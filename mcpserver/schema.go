@@ -0,0 +1,50 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// withInputSchema creates a ToolOption that sets the tool's complete input schema from its parameters
+func withInputSchema(params []*mcptypes.Parameter) mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		t.InputSchema = mcp.ToolInputSchema(toArgumentsSchema(params))
+	}
+}
+
+// withRawInputSchema creates a ToolOption that publishes a JSON Schema as the tool's input schema.
+// mcp-go refuses to marshal a tool with both schemas, so the default object schema is cleared.
+func withRawInputSchema(schema json.RawMessage) mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		t.InputSchema = mcp.ToolInputSchema{}
+		t.RawInputSchema = schema
+	}
+}
+
+// withOutputSchema creates a ToolOption that declares the schema of the tool's structured content
+func withOutputSchema(params []*mcptypes.Parameter) mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		t.OutputSchema = mcp.ToolOutputSchema(toArgumentsSchema(params))
+	}
+}
+
+// toArgumentsSchema builds the mcp-go object schema for a parameter list
+func toArgumentsSchema(params []*mcptypes.Parameter) mcp.ToolArgumentsSchema {
+	schema := mcptypes.ObjectSchema(params)
+	argsSchema := mcp.ToolArgumentsSchema{
+		Type:       "object",
+		Properties: schema["properties"].(map[string]any),
+	}
+	if required, ok := schema["required"].([]string); ok {
+		argsSchema.Required = required
+	}
+	return argsSchema
+}
@@ -0,0 +1,154 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// DefaultStdioTokenEnv is the environment variable the stdio transport reads a bearer token
+// from, unless changed with WithStdioTokenEnv
+const DefaultStdioTokenEnv = "MCP_AUTH_TOKEN"
+
+// authorizationMetaKey is the _meta field of the initialize request in which clients of
+// session transports such as stdio send "Bearer <token>", as they would in an HTTP header
+const authorizationMetaKey = "authorization"
+
+// sessionAuthContextKey is the context key under which the sessionAuth of a session
+// transport is stored
+type sessionAuthContextKey struct{}
+
+// sessionAuth authenticates the single client of a session transport such as stdio, which
+// has one context for the life of the connection and no per-request headers. The client
+// authenticates once, with a token supplied when the transport starts or sent in the
+// initialize request, and every later request of the session uses those credentials.
+type sessionAuth struct {
+	validator mcptypes.BearerTokenValidator
+	scopes    []string
+
+	mu    sync.RWMutex
+	creds *credentials
+	info  *mcptypes.AuthInfo
+}
+
+// login authenticates the session with a token, replacing any earlier credentials
+func (s *sessionAuth) login(token string) error {
+	creds := &credentials{token: token, validator: s.validator, scopes: s.scopes}
+	info, err := creds.authenticate()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.creds = creds
+	s.info = info
+	return nil
+}
+
+// current returns the session's credentials and auth info, or nil if it has not authenticated
+func (s *sessionAuth) current() (*credentials, *mcptypes.AuthInfo) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.creds, s.info
+}
+
+// newSessionAuth returns the authentication of a session whose client must authenticate with
+// the validator. If token is not empty, the session is authenticated with it immediately and
+// an error is returned if it is rejected.
+func (m *MCPServer) newSessionAuth(validator mcptypes.BearerTokenValidator, token string) (*sessionAuth, error) {
+	session := &sessionAuth{validator: validator, scopes: m.requiredScopes}
+	if token != "" {
+		if err := session.login(token); err != nil {
+			return nil, err
+		}
+	}
+	return session, nil
+}
+
+// AuthenticateSession prepares the context of a custom transport that serves one client per
+// connection, so that the client is authenticated like a stdio client: with token, if not
+// empty (for example a token the transport was configured with), or with the token the client
+// sends in the "authorization" field of the initialize request's _meta. Requests are rejected
+// until the session has authenticated. Call it in Transport.Start and pass the returned
+// context to HandleMessage. Tokens are checked by validator, or by the validator set with
+// WithBearerTokenAuth if it is nil; if neither is set, ctx is returned as is.
+func (m *MCPServer) AuthenticateSession(ctx context.Context, validator mcptypes.BearerTokenValidator, token string) (context.Context, error) {
+	if validator == nil {
+		validator = m.bearerTokenValidator
+	}
+	if validator == nil {
+		return ctx, nil
+	}
+	session, err := m.newSessionAuth(validator, token)
+	if err != nil {
+		return nil, err
+	}
+	return context.WithValue(ctx, sessionAuthContextKey{}, session), nil
+}
+
+// hookRequestInitialization authenticates the requests of session transports. The initialize
+// request may carry a token in its metadata; any other request is rejected if the session has
+// not authenticated or its token has expired. Requests on other transports were authenticated
+// by the transport and pass unchanged.
+//
+//goland:noinspection GoUnusedParameter
+func (m *MCPServer) hookRequestInitialization(ctx context.Context, id any, message any) error {
+	session, ok := ctx.Value(sessionAuthContextKey{}).(*sessionAuth)
+	if !ok {
+		return nil
+	}
+	raw, ok := message.(json.RawMessage)
+	if !ok {
+		return nil
+	}
+
+	var request struct {
+		Method string `json:"method"`
+		Params struct {
+			Meta map[string]any `json:"_meta"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(raw, &request); err != nil {
+		return nil // Left to mcp-go to report
+	}
+
+	switch mcp.MCPMethod(request.Method) {
+	case mcp.MethodPing:
+		return nil
+	case mcp.MethodInitialize:
+		if value, ok := request.Params.Meta[authorizationMetaKey]; ok {
+			authorization, _ := value.(string)
+			token, err := parseAuthorization(authorization)
+			if err == nil {
+				err = session.login(token)
+			}
+			if err != nil {
+				m.logger.Warningf("Session authentication failed: %v", err)
+				return err
+			}
+		}
+	}
+
+	_, info := session.current()
+	if info == nil {
+		m.logger.Warningf("Rejected %s: session has not authenticated", request.Method)
+		return errNoCredentials
+	}
+	if !info.ExpiresAt.IsZero() && time.Now().After(info.ExpiresAt) {
+		m.logger.Warningf("Rejected %s: session token expired", request.Method)
+		return fmt.Errorf("%w: token expired", errInvalidToken)
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// tokenValidator accepts only the given token, counting the calls. The "short" token expires
+// shortly after it is validated.
+type tokenValidator struct {
+	token string
+	calls atomic.Int32
+}
+
+func (v *tokenValidator) validate(token string) (map[string]any, error) {
+	v.calls.Add(1)
+	switch token {
+	case v.token:
+		return map[string]any{"sub": token}, nil
+	case "short":
+		return map[string]any{"sub": token, "exp": time.Now().Add(50 * time.Millisecond)}, nil
+	default:
+		return nil, fmt.Errorf("unknown token")
+	}
+}
+
+// sendRequest passes a JSON-RPC request through the request hook of session transports
+func sendRequest(t *testing.T, m *MCPServer, ctx context.Context, method string, meta map[string]any) error {
+	t.Helper()
+	message, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  map[string]any{"_meta": meta},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m.hookRequestInitialization(ctx, 1, json.RawMessage(message))
+}
+
+func TestAuthenticateSession(t *testing.T) {
+	server := &tokenValidator{token: "server"}
+	listener := &tokenValidator{token: "listener"}
+
+	tests := []struct {
+		name      string
+		validator mcptypes.BearerTokenValidator // Passed to AuthenticateSession
+		token     string                        // Passed to AuthenticateSession
+		meta      map[string]any                // _meta of the initialize request
+		wait      time.Duration                 // Delay before the tools/list request
+		want      error                         // Error of the first rejected request
+	}{
+		{name: "token at start", token: "server"},
+		{name: "token in initialize metadata", meta: map[string]any{"authorization": "Bearer server"}},
+		{name: "missing token", want: errNoCredentials},
+		{name: "rejected token in metadata", meta: map[string]any{"authorization": "Bearer nope"}, want: errInvalidToken},
+		{name: "malformed metadata", meta: map[string]any{"authorization": "server"}, want: errMalformedCredentials},
+		{name: "expired session token", token: "short", wait: 100 * time.Millisecond, want: errInvalidToken},
+		{name: "listener validator", validator: listener.validate, token: "listener"},
+		{name: "listener validator in metadata", validator: listener.validate, meta: map[string]any{"authorization": "Bearer listener"}},
+		{name: "server token on a listener", validator: listener.validate, meta: map[string]any{"authorization": "Bearer server"}, want: errInvalidToken},
+	}
+
+	m := newRegistryServer(t, WithBearerTokenAuth(server.validate))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := m.AuthenticateSession(context.Background(), tt.validator, tt.token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = sendRequest(t, m, ctx, string(mcp.MethodPing), nil); err != nil {
+				t.Fatalf("ping rejected: %v", err)
+			}
+
+			err = sendRequest(t, m, ctx, string(mcp.MethodInitialize), tt.meta)
+			if err == nil {
+				time.Sleep(tt.wait)
+				err = sendRequest(t, m, ctx, string(mcp.MethodToolsList), nil)
+			}
+			if tt.want == nil && err != nil {
+				t.Errorf("request rejected: %v", err)
+			} else if !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticateSessionRejectsToken(t *testing.T) {
+	v := &tokenValidator{token: "server"}
+	m := newRegistryServer(t, WithBearerTokenAuth(v.validate))
+	if _, err := m.AuthenticateSession(context.Background(), nil, "nope"); !errors.Is(err, errInvalidToken) {
+		t.Errorf("error = %v, want %v", err, errInvalidToken)
+	}
+}
+
+func TestAuthenticateSessionWithoutValidator(t *testing.T) {
+	m := newRegistryServer(t)
+	ctx, err := m.AuthenticateSession(context.Background(), nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sendRequest(t, m, ctx, string(mcp.MethodToolsList), nil); err != nil {
+		t.Errorf("tools/list rejected without a validator: %v", err)
+	}
+}
+
+func TestStdioTokenEnv(t *testing.T) {
+	server := &tokenValidator{token: "server"}
+	listener := &tokenValidator{token: "listener"}
+
+	tests := []struct {
+		name     string
+		listener *Listener
+		token    string
+		valid    bool
+	}{
+		{"server validator", StdioListener(), "server", true},
+		{"rejected token", StdioListener(), "nope", false},
+		{"listener validator", StdioListener().WithBearerTokenAuth(listener.validate), "listener", true},
+		{"server token on a listener", StdioListener().WithBearerTokenAuth(listener.validate), "server", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newRegistryServer(t, WithBearerTokenAuth(server.validate), WithStdioTokenEnv("TEST_MCP_TOKEN"))
+			t.Setenv("TEST_MCP_TOKEN", tt.token)
+
+			stdio := &stdioTransport{m: m, listener: tt.listener}
+			err := stdio.Start(context.Background(), m.srv)
+			if err == nil {
+				_ = stdio.Shutdown(context.Background())
+			}
+			if tt.valid && err != nil {
+				t.Errorf("valid token rejected: %v", err)
+			} else if !tt.valid && !errors.Is(err, errInvalidToken) {
+				t.Errorf("error = %v, want %v", err, errInvalidToken)
+			}
+			if _, set := os.LookupEnv("TEST_MCP_TOKEN"); set {
+				t.Error("token left in the environment")
+			}
+		})
+	}
+}
+
+func TestHTTPToolCallValidatedOnce(t *testing.T) {
+	v := &tokenValidator{token: "server"}
+	m := newRegistryServer(t,
+		WithBearerTokenAuth(v.validate),
+		WithToolProviders([]mcptypes.ToolProvider{&listProvider{tools: []mcptypes.ToolDefinition{testTool("t", "ok")}}}),
+	)
+	ts := httptest.NewServer(m.StreamableHandler())
+	t.Cleanup(func() {
+		_ = m.Stop()
+		ts.Close()
+	})
+
+	c, err := client.NewStreamableHttpClient(ts.URL+m.StreamableEndpoint(),
+		transport.WithHTTPHeaders(map[string]string{"Authorization": "Bearer server"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err = c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	if _, err = c.Initialize(ctx, initRequest); err != nil {
+		t.Fatal(err)
+	}
+
+	before := v.calls.Load()
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "t"
+	if _, err = c.CallTool(ctx, request); err != nil {
+		t.Fatal(err)
+	}
+	if calls := v.calls.Load() - before; calls != 1 {
+		t.Errorf("validator called %d times for one tool call, want 1", calls)
+	}
+}
+
+// whoamiTool returns the subject of the caller, or "anonymous"
+func whoamiTool() mcptypes.ToolDefinition {
+	return mcptypes.ToolDefinition{
+		Name: "whoami",
+		HandlerContext: func(ctx context.Context, _ mcptypes.ToolRequest, _ map[string]any) (string, error) {
+			info, ok := AuthFromContext(ctx)
+			if !ok {
+				return "anonymous", nil
+			}
+			return info.Subject, nil
+		},
+	}
+}
+
+// startStdio starts a stdio transport connected to pipes in place of stdin and stdout, and
+// returns functions that send a request and read its response
+func startStdio(t *testing.T, m *MCPServer) (send func(string), receive func() map[string]any) {
+	t.Helper()
+	stdinReader, stdinWriter, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdoutReader, stdoutWriter, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin, stdout := os.Stdin, os.Stdout
+	os.Stdin, os.Stdout = stdinReader, stdoutWriter
+	t.Cleanup(func() { os.Stdin, os.Stdout = stdin, stdout })
+
+	stdio := &stdioTransport{m: m, listener: StdioListener()}
+	if err = stdio.Start(context.Background(), m.srv); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = stdinWriter.Close()
+		_ = stdio.Shutdown(context.Background())
+		_ = stdoutReader.Close()
+	})
+
+	decoder := json.NewDecoder(stdoutReader)
+	send = func(message string) {
+		t.Helper()
+		if _, err := stdinWriter.WriteString(message + "\n"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	receive = func() map[string]any {
+		t.Helper()
+		_ = stdoutReader.SetReadDeadline(time.Now().Add(5 * time.Second))
+		var response map[string]any
+		if err := decoder.Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+		return response
+	}
+	return send, receive
+}
+
+func TestStdioCredentials(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string         // Value of MCP_AUTH_TOKEN
+		meta map[string]any // _meta of the initialize request
+		want string         // Caller seen by the tool, or "" if the session is rejected
+	}{
+		{name: "token in MCP_AUTH_TOKEN", env: "read", want: "alice"},
+		{name: "token in initialize metadata", meta: map[string]any{"authorization": "Bearer read"}, want: "alice"},
+		{name: "metadata replaces the environment", env: "read", meta: map[string]any{"authorization": "Bearer none"}, want: "bob"},
+		{name: "no token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newRegistryServer(t,
+				WithBearerTokenAuth(scopedValidator),
+				WithToolProviders([]mcptypes.ToolProvider{&listProvider{tools: []mcptypes.ToolDefinition{whoamiTool()}}}))
+			t.Setenv(DefaultStdioTokenEnv, tt.env)
+			send, receive := startStdio(t, m)
+
+			params := map[string]any{
+				"protocolVersion": mcp.LATEST_PROTOCOL_VERSION,
+				"clientInfo":      map[string]any{"name": "test", "version": "1"},
+				"capabilities":    map[string]any{},
+				"_meta":           tt.meta,
+			}
+			initialize, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": params})
+			send(string(initialize))
+			response := receive()
+			if tt.want == "" {
+				if response["error"] == nil {
+					t.Errorf("session accepted without a token: %v", response)
+				}
+				return
+			}
+			if response["error"] != nil {
+				t.Fatalf("initialize rejected: %v", response["error"])
+			}
+
+			send(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"whoami"}}`)
+			response = receive()
+			result, _ := response["result"].(map[string]any)
+			content, _ := result["content"].([]any)
+			if len(content) != 1 {
+				t.Fatalf("response = %v", response)
+			}
+			if text := content[0].(map[string]any)["text"]; text != tt.want {
+				t.Errorf("whoami = %v, want %s", text, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// systemdFirstFD is the first file descriptor passed by systemd socket activation
+const systemdFirstFD = 3
+
+// address returns the listen address including the network for non-TCP listeners
+func (l *Listener) address() string {
+	if l.network == "" || l.network == "tcp" {
+		return l.Listen
+	}
+	return l.network + ":" + l.Listen
+}
+
+// bind returns the net.Listener to serve on, binding the address unless one was supplied
+func (l *Listener) bind() (net.Listener, error) {
+	if l.listener != nil {
+		return l.listener, nil
+	}
+	if l.network != "unix" {
+		ln, err := net.Listen("tcp", l.Listen)
+		if err != nil {
+			return nil, fmt.Errorf("unable to listen on %s: %w", l.Listen, err)
+		}
+		return ln, nil
+	}
+
+	if err := removeStaleSocket(l.Listen); err != nil {
+		return nil, err
+	}
+	if l.fileMode == 0 && l.uid < 0 && l.gid < 0 {
+		ln, err := net.Listen("unix", l.Listen)
+		if err != nil {
+			return nil, fmt.Errorf("unable to listen on %s: %w", l.address(), err)
+		}
+		return ln, nil
+	}
+	return l.bindRestricted()
+}
+
+// bindRestricted binds a Unix domain socket whose mode or owner is configured. The socket is
+// created in a new directory that only this process can enter, its mode and owner are set
+// there, and it is then renamed into place, so no client can connect to it before its
+// permissions apply.
+func (l *Listener) bindRestricted() (net.Listener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(l.Listen), ".mcp-")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create directory for socket %s: %w", l.Listen, err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "s")
+	ln, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen on %s: %w", l.address(), err)
+	}
+	// The socket file is renamed, so it is removed by unixListener.Close instead
+	ln.SetUnlinkOnClose(false)
+
+	if l.fileMode != 0 {
+		if err = os.Chmod(path, l.fileMode); err != nil {
+			_ = ln.Close()
+			return nil, fmt.Errorf("unable to set mode of socket %s: %w", l.Listen, err)
+		}
+	}
+	if l.uid >= 0 || l.gid >= 0 {
+		if err = os.Chown(path, l.uid, l.gid); err != nil {
+			_ = ln.Close()
+			return nil, fmt.Errorf("unable to set owner of socket %s: %w", l.Listen, err)
+		}
+	}
+	if err = os.Rename(path, l.Listen); err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("unable to move socket into place at %s: %w", l.Listen, err)
+	}
+	return &unixListener{UnixListener: ln, path: l.Listen}, nil
+}
+
+// unixListener is a Unix domain socket listener whose socket file was renamed after binding.
+// It reports the final path as its address and removes the file when closed.
+type unixListener struct {
+	*net.UnixListener
+	path string
+}
+
+// Addr returns the path the socket was moved to
+func (u *unixListener) Addr() net.Addr {
+	return &net.UnixAddr{Name: u.path, Net: "unix"}
+}
+
+// Close stops listening and removes the socket file
+func (u *unixListener) Close() error {
+	err := u.UnixListener.Close()
+	if removeErr := os.Remove(u.path); removeErr != nil && !errors.Is(removeErr, fs.ErrNotExist) && err == nil {
+		err = removeErr
+	}
+	return err
+}
+
+// removeStaleSocket removes a socket file left behind by a previous process, refusing to
+// remove a socket that still accepts connections or a path that is not a socket
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to check socket %s: %w", path, err)
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("unable to listen on %s: file exists and is not a socket", path)
+	}
+	if conn, err := net.Dial("unix", path); err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("unable to listen on %s: socket is in use", path)
+	}
+	if err = os.Remove(path); err != nil {
+		return fmt.Errorf("unable to remove stale socket %s: %w", path, err)
+	}
+	return nil
+}
+
+// SystemdListeners returns the listeners passed to this process by systemd socket activation,
+// in the order of the ListenStream= lines of the socket unit. It returns nil if the process was
+// not socket-activated. The LISTEN_* environment variables are cleared so that child processes
+// do not inherit them. Serve the returned listeners with SSEListenerFrom or HTTPListenerFrom.
+func SystemdListeners() ([]net.Listener, error) {
+	defer func() {
+		_ = os.Unsetenv("LISTEN_PID")
+		_ = os.Unsetenv("LISTEN_FDS")
+		_ = os.Unsetenv("LISTEN_FDNAMES")
+	}()
+
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, nil
+	}
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count <= 0 {
+		return nil, nil
+	}
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+
+	listeners := make([]net.Listener, 0, count)
+	for i := 0; i < count; i++ {
+		name := fmt.Sprintf("LISTEN_FD_%d", systemdFirstFD+i)
+		if i < len(names) && names[i] != "" {
+			name = names[i]
+		}
+
+		// FileListener duplicates the descriptor, so the original is closed afterwards
+		file := os.NewFile(uintptr(systemdFirstFD+i), name)
+		ln, err := net.FileListener(file)
+		_ = file.Close()
+		if err != nil {
+			for _, opened := range listeners {
+				_ = opened.Close()
+			}
+			return nil, fmt.Errorf("unable to use socket-activated descriptor %s: %w", name, err)
+		}
+		listeners = append(listeners, ln)
+	}
+	return listeners, nil
+}
@@ -0,0 +1,90 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"errors"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBindUnixSocket(t *testing.T) {
+	tests := []struct {
+		name     string
+		listener func(path string) *Listener
+		want     os.FileMode // Expected permission bits, or 0 to skip the check
+	}{
+		{"default", UnixHTTPListener, 0},
+		{"mode", func(path string) *Listener { return UnixHTTPListener(path).WithSocketMode(0o600) }, 0o600},
+		{"group mode", func(path string) *Listener { return UnixSSEListener(path).WithSocketMode(0o660) }, 0o660},
+		{
+			"owner",
+			func(path string) *Listener {
+				return UnixWebSocketListener(path).WithSocketMode(0o640).WithSocketOwner(os.Getuid(), os.Getgid())
+			},
+			0o640,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "mcp.sock")
+
+			ln, err := tt.listener(path).bind()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ln.Addr().String(); got != path {
+				t.Errorf("Addr() = %s, want %s", got, path)
+			}
+
+			info, err := os.Lstat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Mode()&os.ModeSocket == 0 {
+				t.Fatalf("%s is not a socket: %v", path, info.Mode())
+			}
+			if tt.want != 0 && info.Mode().Perm() != tt.want {
+				t.Errorf("mode = %v, want %v", info.Mode().Perm(), tt.want)
+			}
+
+			// Clients connect through the final path, and the private directory is gone
+			conn, err := net.Dial("unix", path)
+			if err != nil {
+				t.Fatalf("unable to connect: %v", err)
+			}
+			_ = conn.Close()
+			if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+				t.Errorf("directory holds %d entries, want only the socket", len(entries))
+			}
+
+			if err = ln.Close(); err != nil {
+				t.Errorf("Close() = %v", err)
+			}
+			if _, err = os.Lstat(path); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("socket file not removed on close: %v", err)
+			}
+		})
+	}
+}
+
+func TestBindUnixSocketInUse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mcp.sock")
+	first, err := UnixHTTPListener(path).WithSocketMode(0o600).bind()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = first.Close() }()
+
+	if _, err = UnixHTTPListener(path).bind(); err == nil {
+		t.Fatal("bound a socket that is in use")
+	}
+}
@@ -0,0 +1,218 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// tlsReloadInterval is how often the certificate and key files are checked for changes
+var tlsReloadInterval = 10 * time.Second
+
+// certReloader serves a certificate loaded from files and reloads it when the files change,
+// so renewed certificates are picked up without restarting the server
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+// newCertReloader loads the certificate and key, returning an error if they are unusable
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if _, err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// getCertificate implements tls.Config.GetCertificate
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// reload loads the certificate and key if either file changed since the last load.
+// It returns true if a new certificate was loaded.
+func (r *certReloader) reload() (bool, error) {
+	modTime, err := latestModTime(r.certFile, r.keyFile)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	unchanged := r.cert != nil && modTime.Equal(r.modTime)
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("unable to load TLS certificate: %w", err)
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.modTime = modTime
+	r.mu.Unlock()
+	return true, nil
+}
+
+// latestModTime returns the most recent modification time of the given files
+func latestModTime(files ...string) (time.Time, error) {
+	var latest time.Time
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unable to read TLS file: %w", err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// resolveTLS builds the TLS configuration used by network listeners from the TLS options.
+// It leaves m.tlsConfig nil if TLS is not configured.
+func (m *MCPServer) resolveTLS() error {
+	if m.tlsConfig == nil && m.tlsCertFile == "" && m.tlsClientCAFile == "" {
+		return nil
+	}
+
+	var cfg *tls.Config
+	if m.tlsConfig != nil {
+		cfg = m.tlsConfig.Clone()
+	} else {
+		cfg = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	// Serve the certificate from files, reloading it when they change
+	if m.tlsCertFile != "" {
+		reloader, err := newCertReloader(m.tlsCertFile, m.tlsKeyFile)
+		if err != nil {
+			return err
+		}
+		m.certReloader = reloader
+		cfg.GetCertificate = reloader.getCertificate
+	}
+
+	if len(cfg.Certificates) == 0 && cfg.GetCertificate == nil && cfg.GetConfigForClient == nil {
+		return fmt.Errorf("TLS is configured without a certificate; use WithTLS() or set one in WithTLSConfig()")
+	}
+
+	// Require client certificates signed by the given CAs for mutual TLS
+	if m.tlsClientCAFile != "" {
+		pem, err := os.ReadFile(m.tlsClientCAFile)
+		if err != nil {
+			return fmt.Errorf("unable to read client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in client CA file %s", m.tlsClientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	m.tlsConfig = cfg
+	return nil
+}
+
+// tlsConfigFor returns the TLS configuration of a network listener, or nil if it is served
+// without TLS. TLS applies to every network listener, Unix domain sockets included, unless the
+// listener opts out with WithoutTLS.
+func (m *MCPServer) tlsConfigFor(l *Listener) *tls.Config {
+	if l.noTLS {
+		return nil
+	}
+	return m.tlsConfig
+}
+
+// watchCertificate reloads the TLS certificate when its files change, until ctx is cancelled
+func (m *MCPServer) watchCertificate(ctx context.Context) {
+	if m.certReloader == nil {
+		return
+	}
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(tlsReloadInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				reloaded, err := m.certReloader.reload()
+				if err != nil {
+					m.logger.Errorf("Keeping current TLS certificate: %v", err)
+				} else if reloaded {
+					m.logger.Infof("Reloaded TLS certificate from %s", m.certReloader.certFile)
+				}
+			}
+		}
+	}()
+}
+
+// clientCertHTTPMiddleware stores the identity of a verified TLS client certificate in the
+// auth context. Bearer token authentication, if configured, runs after it and replaces it
+// with the identity of the token.
+type clientCertHTTPMiddleware struct {
+	handler http.Handler
+}
+
+// ServeHTTP implements http.Handler
+func (c *clientCertHTTPMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+		ctx := ContextWithAuth(r.Context(), clientCertAuthInfo(r.TLS.VerifiedChains[0][0]))
+		r = r.WithContext(ctx)
+	}
+	c.handler.ServeHTTP(w, r)
+}
+
+// clientCertAuthInfo describes the caller identified by a client certificate
+func clientCertAuthInfo(cert *x509.Certificate) *mcptypes.AuthInfo {
+	uris := make([]string, 0, len(cert.URIs))
+	for _, uri := range cert.URIs {
+		uris = append(uris, uri.String())
+	}
+	ips := make([]string, 0, len(cert.IPAddresses))
+	for _, ip := range cert.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+
+	claims := map[string]any{
+		"authenticated":          true,
+		"tls_client_subject":     cert.Subject.String(),
+		"tls_client_common_name": cert.Subject.CommonName,
+		"tls_client_issuer":      cert.Issuer.String(),
+		"tls_client_serial":      cert.SerialNumber.String(),
+		"tls_client_dns_names":   cert.DNSNames,
+		"tls_client_emails":      cert.EmailAddresses,
+		"tls_client_uris":        uris,
+		"tls_client_ips":         ips,
+	}
+	return &mcptypes.AuthInfo{
+		Subject: cert.Subject.String(),
+		Claims:  claims,
+		Method:  mcptypes.AuthMethodTLS,
+	}
+}
@@ -0,0 +1,321 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// testCA issues certificates for TLS tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pool *x509.CertPool
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &testCA{cert: cert, key: key, pool: pool}
+}
+
+// issue creates a server certificate for localhost, or a client certificate for the common name
+func (ca *testCA) issue(t *testing.T, serial int64, commonName string, client bool) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	if client {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	} else {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		template.DNSNames = []string{"localhost"}
+		template.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1)}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// writeCertificate writes a certificate and its key as PEM files, setting their modification time
+func writeCertificate(t *testing.T, cert tls.Certificate, certFile, keyFile string, modTime time.Time) {
+	t.Helper()
+	keyDER, err := x509.MarshalECPrivateKey(cert.PrivateKey.(*ecdsa.PrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, certFile, "CERTIFICATE", cert.Certificate[0])
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+	for _, file := range []string{certFile, keyFile} {
+		if err = os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func writePEM(t *testing.T, file, blockType string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// startTLSServer starts a server with a streamable HTTP listener on a loopback port and
+// returns the address it listens on
+func startTLSServer(t *testing.T, options ...Option) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := New(append([]Option{WithTransports(HTTPListenerFrom(ln))}, options...)...)
+	if err != nil {
+		_ = ln.Close()
+		t.Fatal(err)
+	}
+	if err = m.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = m.Stop() })
+	return ln.Addr().String()
+}
+
+// servedSerial returns the serial number of the certificate the server presents
+func servedSerial(t *testing.T, addr string, roots *x509.CertPool) int64 {
+	t.Helper()
+	conn, err := tls.Dial("tcp", addr, &tls.Config{RootCAs: roots, ServerName: "localhost"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+	return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+}
+
+func TestTLSReloadsCertificate(t *testing.T) {
+	interval := tlsReloadInterval
+	tlsReloadInterval = 20 * time.Millisecond
+	t.Cleanup(func() { tlsReloadInterval = interval })
+
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	loaded := time.Now().Add(-time.Minute)
+	writeCertificate(t, ca.issue(t, 10, "localhost", false), certFile, keyFile, loaded)
+
+	addr := startTLSServer(t, WithTLS(certFile, keyFile))
+	if serial := servedSerial(t, addr, ca.pool); serial != 10 {
+		t.Fatalf("served certificate %d, want 10", serial)
+	}
+
+	// An unreadable replacement leaves the current certificate in place
+	if err := os.WriteFile(certFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * tlsReloadInterval)
+	if serial := servedSerial(t, addr, ca.pool); serial != 10 {
+		t.Fatalf("served certificate %d after a bad replacement, want 10", serial)
+	}
+
+	writeCertificate(t, ca.issue(t, 11, "localhost", false), certFile, keyFile, loaded.Add(30*time.Second))
+	deadline := time.Now().Add(5 * time.Second)
+	for servedSerial(t, addr, ca.pool) != 11 {
+		if time.Now().After(deadline) {
+			t.Fatal("renewed certificate not served")
+		}
+		time.Sleep(tlsReloadInterval)
+	}
+}
+
+func TestTLSConfigWithoutCertificate(t *testing.T) {
+	if _, err := New(WithHandlerOnly(), WithTLSConfig(&tls.Config{})); err == nil {
+		t.Error("TLS configured without a certificate")
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	writeCertificate(t, ca.issue(t, 10, "localhost", false), certFile, keyFile, time.Now())
+	caFile := filepath.Join(dir, "ca.pem")
+	writePEM(t, caFile, "CERTIFICATE", ca.cert.Raw)
+
+	whoami := mcptypes.ToolDefinition{
+		Name: "whoami",
+		HandlerContext: func(ctx context.Context, _ mcptypes.ToolRequest, _ map[string]any) (string, error) {
+			info, ok := AuthFromContext(ctx)
+			if !ok || info.Method != mcptypes.AuthMethodTLS {
+				return "anonymous", nil
+			}
+			return info.Claims["tls_client_common_name"].(string) + " " + info.Subject, nil
+		},
+	}
+	addr := startTLSServer(t,
+		WithTLS(certFile, keyFile),
+		WithClientCAs(caFile),
+		WithToolProviders([]mcptypes.ToolProvider{&listProvider{tools: []mcptypes.ToolDefinition{whoami}}}))
+
+	// Clients without a certificate, or with one from another CA, are rejected
+	other := newTestCA(t)
+	for name, certs := range map[string][]tls.Certificate{
+		"no certificate":   nil,
+		"untrusted issuer": {other.issue(t, 20, "mallory", true)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			conn, err := tls.Dial("tcp", addr, &tls.Config{RootCAs: ca.pool, ServerName: "localhost", Certificates: certs})
+			if err == nil {
+				// TLS 1.3 reports the client certificate failure on the first read
+				_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+				_, err = conn.Read(make([]byte, 1))
+				_ = conn.Close()
+			}
+			if err == nil {
+				t.Error("handshake succeeded")
+			}
+		})
+	}
+
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		RootCAs:      ca.pool,
+		Certificates: []tls.Certificate{ca.issue(t, 30, "alice", true)},
+	}}}
+	c, err := client.NewStreamableHttpClient("https://"+addr+"/mcp", transport.WithHTTPBasicClient(httpClient))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err = c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	if _, err = c.Initialize(ctx, initRequest); err != nil {
+		t.Fatal(err)
+	}
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "whoami"
+	result, err := c.CallTool(ctx, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; text != "alice CN=alice" {
+		t.Errorf("whoami = %q, want the client certificate identity", text)
+	}
+}
+
+func TestBearerTokenReplacesClientCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	writeCertificate(t, ca.issue(t, 10, "localhost", false), certFile, keyFile, time.Now())
+	caFile := filepath.Join(dir, "ca.pem")
+	writePEM(t, caFile, "CERTIFICATE", ca.cert.Raw)
+
+	whoami := mcptypes.ToolDefinition{
+		Name: "whoami",
+		HandlerContext: func(ctx context.Context, _ mcptypes.ToolRequest, _ map[string]any) (string, error) {
+			info, _ := AuthFromContext(ctx)
+			_, tlsClaim := info.Claims["tls_client_common_name"]
+			return fmt.Sprintf("%s %s %t", info.Method, info.Subject, tlsClaim), nil
+		},
+	}
+	addr := startTLSServer(t,
+		WithTLS(certFile, keyFile),
+		WithClientCAs(caFile),
+		WithBearerTokenAuth(scopedValidator),
+		WithToolProviders([]mcptypes.ToolProvider{&listProvider{tools: []mcptypes.ToolDefinition{whoami}}}))
+
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		RootCAs:      ca.pool,
+		Certificates: []tls.Certificate{ca.issue(t, 30, "alice", true)},
+	}}}
+	c, err := client.NewStreamableHttpClient("https://"+addr+"/mcp",
+		transport.WithHTTPBasicClient(httpClient),
+		transport.WithHTTPHeaders(map[string]string{"Authorization": "Bearer none"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err = c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	if _, err = c.Initialize(ctx, initRequest); err != nil {
+		t.Fatal(err)
+	}
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "whoami"
+	result, err := c.CallTool(ctx, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The token's caller replaces the certificate's, without its claims
+	want := fmt.Sprintf("%s bob false", mcptypes.AuthMethodBearer)
+	if text := result.Content[0].(mcp.TextContent).Text; text != want {
+		t.Errorf("whoami = %q, want %q", text, want)
+	}
+}
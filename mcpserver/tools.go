@@ -7,8 +7,10 @@ package mcpserver
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
 )
@@ -25,46 +27,129 @@ const (
 func (m *MCPServer) AddTools() {
 
 	// Iterate over tool providers and register their tools
-	for _, provider := range m.toolProviders {
+	for i := range m.toolProviders {
+		m.syncToolProvider(i)
+	}
+}
+
+// newServerTool converts a tool definition to an mcp-go tool with its handler
+func (m *MCPServer) newServerTool(toolDef mcptypes.ToolDefinition) server.ServerTool {
+
+	// Start with description
+	toolOptions := []mcp.ToolOption{
+		mcp.WithDescription(toolDef.Description),
+	}
+
+	// Add the input schema, either as given or built from the parameters
+	if toolDef.RawInputSchema != nil {
+		toolOptions = append(toolOptions, withRawInputSchema(toolDef.RawInputSchema))
+	} else {
+		toolOptions = append(toolOptions, withInputSchema(toolDef.Parameters))
+	}
+
+	// Add output schema for structured content
+	if toolDef.RawOutputSchema != nil {
+		toolOptions = append(toolOptions, mcp.WithRawOutputSchema(toolDef.RawOutputSchema))
+	} else if len(toolDef.OutputSchema) > 0 {
+		toolOptions = append(toolOptions, withOutputSchema(toolDef.OutputSchema))
+	}
+
+	// Add hints with three-level resolution
+	hints := m.resolveHints(&toolDef)
+	toolOptions = append(toolOptions, mcp.WithToolAnnotation(hints))
+
+	// Create the tool with all options
+	tool := mcp.NewTool(toolDef.Name, toolOptions...)
+
+	return server.ServerTool{
+		Tool: tool,
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 
-		// Call the Register function of the provider to get tool definitions
-		toolDefinitions := provider.RegisterTools()
+			// Check the caller may use the tool
+			if err := m.authorize(ctx, toolDef.Scopes, toolDef.Roles); err != nil {
+				m.logger.Warningf("Tool '%s' denied: %v", toolDef.Name, err)
+				return nil, err
+			}
 
-		// Iterate over the tool definitions and register each tool
-		for _, toolDef := range toolDefinitions {
+			// Copy the MCP arguments to a map. Unless the tool opted out, apply defaults and
+			// coercion and validate the arguments against the declared parameters.
+			var options map[string]any
+			if toolDef.SkipValidation {
+				options = m.prepareArguments(nil, req.GetArguments())
+			} else {
+				options = m.prepareArguments(toolDef.Parameters, req.GetArguments())
+				if m.validateArguments {
+					if err := validateArguments(toolDef.Parameters, options); err != nil {
+						m.logger.Warningf("Tool '%s' called with invalid arguments: %v", toolDef.Name, err)
+						return validationErrorResult(err), nil
+					}
+				}
+			}
 
-			// Start with description
-			toolOptions := []mcp.ToolOption{
-				mcp.WithDescription(toolDef.Description),
+			// Execute the tool's handler, passing the options and a context the client can cancel
+			ctx, done := m.trackCall(ctx, req)
+			defer done()
+			result, err := m.callToolHandler(ctx, &toolDef, req, options)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), err
 			}
 
-			// Add parameters
-			for _, param := range toolDef.Parameters {
-				toolOptions = append(toolOptions, m.parameterToToolOption(param)...)
+			// Check structured content against the declared output schema
+			if err = validateStructuredContent(toolDef.OutputSchema, result); err != nil {
+				m.logger.Warningf("Tool '%s' returned invalid structured content: %v", toolDef.Name, err)
+				return mcp.NewToolResultError(fmt.Sprintf("invalid structured content: %v", err)), nil
 			}
+			return toCallToolResult(result)
+		},
+	}
+}
 
-			// Add hints with three-level resolution
-			hints := m.resolveHints(&toolDef)
-			toolOptions = append(toolOptions, mcp.WithToolAnnotation(hints))
+// callToolHandler executes the most capable handler set on the tool and normalizes its result
+func (m *MCPServer) callToolHandler(ctx context.Context, toolDef *mcptypes.ToolDefinition, req mcp.CallToolRequest, options map[string]any) (*mcptypes.ToolResult, error) {
+	switch {
+	case toolDef.HandlerResult != nil:
+		result, err := toolDef.HandlerResult(ctx, newToolRequest(ctx, req), options)
+		if err == nil && result == nil {
+			result = mcptypes.NewToolResult()
+		}
+		return result, err
 
-			// Create the tool with all options
-			tool := mcp.NewTool(toolDef.Name, toolOptions...)
+	case toolDef.HandlerContext != nil:
+		text, err := toolDef.HandlerContext(ctx, newToolRequest(ctx, req), options)
+		return mcptypes.NewToolResult(mcptypes.NewTextContent(text)), err
 
-			// Register the tool with the MCP server
-			m.srv.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	case toolDef.Handler != nil:
+		text, err := toolDef.Handler(options)
+		return mcptypes.NewToolResult(mcptypes.NewTextContent(text)), err
 
-				// Copy the MCP arguments to a map
-				options := req.GetArguments()
+	default:
+		return nil, fmt.Errorf("tool '%s' has no handler", toolDef.Name)
+	}
+}
 
-				// Execute the tool's handler, passing the options
-				result, err := toolDef.Handler(options)
-				if err != nil {
-					return mcp.NewToolResultError(err.Error()), err
-				}
-				return mcp.NewToolResultText(result), nil
-			})
+// newToolRequest builds the request descriptor passed to context-aware tool handlers
+func newToolRequest(ctx context.Context, req mcp.CallToolRequest) mcptypes.ToolRequest {
+	toolReq := mcptypes.ToolRequest{
+		ToolName: req.Params.Name,
+	}
+
+	// Session ID is available on every transport that tracks sessions
+	toolReq.SessionID = sessionID(ctx)
+
+	// Request ID is stamped into the metadata by hookBeforeCallTool
+	if req.Params.Meta != nil {
+		if id, ok := req.Params.Meta.AdditionalFields[requestIDMetaKey].(string); ok {
+			toolReq.RequestID = id
 		}
 	}
+
+	// Auth info is stored by the authentication middleware of the transport
+	if info, ok := AuthFromContext(ctx); ok {
+		toolReq.Auth = info.Claims
+		toolReq.AuthInfo = info
+	}
+
+	return toolReq
 }
 
 // resolveHints implements three-level hint resolution:
@@ -117,99 +202,3 @@ func (m *MCPServer) resolveHints(toolDef *mcptypes.ToolDefinition) mcp.ToolAnnot
 
 	return hints
 }
-
-// parameterToToolOption converts an mcptypes.Parameter to mcp.ToolOption slice
-// Supporting full JSON Schema validation
-func (m *MCPServer) parameterToToolOption(param *mcptypes.Parameter) []mcp.ToolOption {
-	var options []mcp.ToolOption
-
-	// Build property options based on parameter type
-	switch param.Type {
-	case "string":
-		propOpts := []mcp.PropertyOption{mcp.Description(param.Description)}
-		if param.Required {
-			propOpts = append(propOpts, mcp.Required())
-		}
-		// String-specific validations
-		if param.Pattern != nil {
-			propOpts = append(propOpts, mcp.Pattern(*param.Pattern))
-		}
-		if param.MinLength != nil {
-			propOpts = append(propOpts, mcp.MinLength(*param.MinLength))
-		}
-		if param.MaxLength != nil {
-			propOpts = append(propOpts, mcp.MaxLength(*param.MaxLength))
-		}
-		// Note: mcp.Format may not be available in current version
-		// if param.Format != nil {
-		// 	propOpts = append(propOpts, mcp.Format(*param.Format))
-		// }
-		if param.Enum != nil {
-			// Convert []any to []string for enum
-			strEnum := make([]string, len(param.Enum))
-			for i, v := range param.Enum {
-				if s, ok := v.(string); ok {
-					strEnum[i] = s
-				}
-			}
-			propOpts = append(propOpts, mcp.Enum(strEnum...))
-		}
-		options = append(options, mcp.WithString(param.Name, propOpts...))
-
-	case "number", "integer":
-		propOpts := []mcp.PropertyOption{mcp.Description(param.Description)}
-		if param.Required {
-			propOpts = append(propOpts, mcp.Required())
-		}
-		// Note: Numeric validations may not be fully supported in current mcp-go version
-		// if param.Minimum != nil {
-		// 	propOpts = append(propOpts, mcp.Minimum(*param.Minimum))
-		// }
-		// if param.Maximum != nil {
-		// 	propOpts = append(propOpts, mcp.Maximum(*param.Maximum))
-		// }
-		// Note: mcp-go may not support all numeric validations like MultipleOf, ExclusiveMin/Max
-		// Add them if the library supports them
-		options = append(options, mcp.WithNumber(param.Name, propOpts...))
-
-	case "boolean":
-		propOpts := []mcp.PropertyOption{mcp.Description(param.Description)}
-		if param.Required {
-			propOpts = append(propOpts, mcp.Required())
-		}
-		options = append(options, mcp.WithBoolean(param.Name, propOpts...))
-
-	case "array":
-		propOpts := []mcp.PropertyOption{mcp.Description(param.Description)}
-		if param.Required {
-			propOpts = append(propOpts, mcp.Required())
-		}
-		// Array validations
-		if param.MinItems != nil {
-			propOpts = append(propOpts, mcp.MinItems(*param.MinItems))
-		}
-		if param.MaxItems != nil {
-			propOpts = append(propOpts, mcp.MaxItems(*param.MaxItems))
-		}
-		// Note: mcp-go may require item schema - check library documentation
-		options = append(options, mcp.WithArray(param.Name, propOpts...))
-
-	case "object":
-		propOpts := []mcp.PropertyOption{mcp.Description(param.Description)}
-		if param.Required {
-			propOpts = append(propOpts, mcp.Required())
-		}
-		// Note: Object properties may need special handling depending on mcp-go library
-		options = append(options, mcp.WithObject(param.Name, propOpts...))
-
-	default:
-		// Fallback to string for unknown types
-		propOpts := []mcp.PropertyOption{mcp.Description(param.Description)}
-		if param.Required {
-			propOpts = append(propOpts, mcp.Required())
-		}
-		options = append(options, mcp.WithString(param.Name, propOpts...))
-	}
-
-	return options
-}
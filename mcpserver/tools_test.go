@@ -0,0 +1,70 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcpserver"
+	"github.com/PivotLLM/MCPLaunchPad/mcptest"
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// echoArguments returns the arguments a tool handler received as JSON
+func echoArguments(options map[string]any) (string, error) {
+	data, err := json.Marshal(options)
+	return string(data), err
+}
+
+func TestRawSchemaTool(t *testing.T) {
+	const schema = `{"type":"object","properties":{"n":{"anyOf":[{"type":"integer"},{"type":"string"}]}},"required":["n"]}`
+	tool := mcptypes.ToolDefinition{
+		Name:           "raw",
+		RawInputSchema: json.RawMessage(schema),
+		// Parameters that would default and coerce the arguments if they were applied
+		Parameters: []*mcptypes.Parameter{
+			mcptypes.IntegerParam("n", "", false),
+			mcptypes.StringParam("s", "", false).WithDefault("default"),
+		},
+		SkipValidation: true,
+		Handler:        echoArguments,
+	}
+	c := mcptest.NewClient(t,
+		mcpserver.WithArgumentCoercion(mcpserver.CoercionPolicy{StringToNumber: true}),
+		mcpserver.WithToolProviders([]mcptypes.ToolProvider{listTools{tool}}),
+	)
+
+	var want map[string]any
+	if err := json.Unmarshal([]byte(schema), &want); err != nil {
+		t.Fatal(err)
+	}
+	if got := mcptest.InputSchema(t, c.Tool("raw")); !reflect.DeepEqual(got, want) {
+		t.Errorf("input schema = %v, want %v", got, want)
+	}
+
+	mcptest.AssertText(t, c.CallTool("raw", map[string]any{"n": "5"}), `{"n":"5"}`)
+}
+
+func TestInvalidRawSchemaRejected(t *testing.T) {
+	c := mcptest.NewClient(t)
+	err := c.Server().RegisterTool(mcptypes.ToolDefinition{
+		Name:           "broken",
+		RawInputSchema: json.RawMessage(`{"type":`),
+		Handler:        echoArguments,
+	})
+	if err == nil {
+		t.Fatal("registered a tool with an invalid input schema")
+	}
+}
+
+// listTools is a tool provider returning a fixed list of tools
+type listTools []mcptypes.ToolDefinition
+
+func (l listTools) RegisterTools() []mcptypes.ToolDefinition {
+	return l
+}
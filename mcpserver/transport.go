@@ -0,0 +1,161 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Transport connects clients to the MCP server. The built-in stdio, SSE, HTTP and WebSocket
+// listeners are transports; custom transports such as named pipes or message queues can be
+// added with WithTransport(). A transport typically registers a server.ClientSession per
+// client and passes each incoming JSON-RPC message to srv.HandleMessage.
+type Transport interface {
+	// Name describes the transport for log and error messages
+	Name() string
+
+	// Start begins serving srv and returns once the transport is ready for clients, or an
+	// error if it cannot start. Serving continues in the background until Shutdown is called;
+	// ctx is cancelled when the server stops.
+	Start(ctx context.Context, srv *server.MCPServer) error
+
+	// Shutdown stops serving and closes open client sessions, waiting for them until ctx expires
+	Shutdown(ctx context.Context) error
+}
+
+// transportFor returns the built-in transport for a listener
+func (m *MCPServer) transportFor(l *Listener) Transport {
+	if l.Mode == TransportStdio {
+		return &stdioTransport{m: m, listener: l}
+	}
+	return &networkTransport{m: m, listener: l}
+}
+
+// stdioTransport serves a single client over stdin/stdout until EOF, a signal or Shutdown
+type stdioTransport struct {
+	m        *MCPServer
+	listener *Listener
+	cancel   context.CancelFunc
+	done     chan struct{}
+	err      error
+}
+
+// Name implements Transport
+func (t *stdioTransport) Name() string {
+	return "stdio"
+}
+
+// Start implements Transport. Like server.ServeStdio, it stops on SIGTERM and SIGINT. If a
+// bearer token validator applies, the client authenticates with the token in the environment
+// variable set by WithStdioTokenEnv, or in the metadata of its initialize request; a token in
+// the environment that is rejected stops the server from starting.
+func (t *stdioTransport) Start(ctx context.Context, srv *server.MCPServer) error {
+	stdio := server.NewStdioServer(srv)
+	if validator := t.m.validatorFor(t.listener); validator != nil {
+		token := os.Getenv(t.m.stdioTokenEnv)
+		_ = os.Unsetenv(t.m.stdioTokenEnv)
+		session, err := t.m.newSessionAuth(validator, token)
+		if err != nil {
+			return fmt.Errorf("token in %s: %w", t.m.stdioTokenEnv, err)
+		}
+		stdio.SetContextFunc(func(ctx context.Context) context.Context {
+			return context.WithValue(ctx, sessionAuthContextKey{}, session)
+		})
+	}
+
+	ctx, t.cancel = signal.NotifyContext(ctx, syscall.SIGTERM, os.Interrupt)
+	t.done = make(chan struct{})
+
+	go func() {
+		defer close(t.done)
+		defer t.cancel()
+		err := stdio.Listen(ctx, os.Stdin, os.Stdout)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			t.err = err
+		}
+	}()
+	return nil
+}
+
+// wait blocks until the client disconnects and returns the error that ended the session, if any
+func (t *stdioTransport) wait() error {
+	<-t.done
+	return t.err
+}
+
+// Shutdown implements Transport
+func (t *stdioTransport) Shutdown(ctx context.Context) error {
+	if t.cancel == nil {
+		return nil
+	}
+	t.cancel()
+	select {
+	case <-t.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// networkTransport serves an SSE, HTTP or WebSocket listener
+type networkTransport struct {
+	m        *MCPServer
+	listener *Listener
+	shutdown func(ctx context.Context) error
+}
+
+// Name implements Transport
+func (t *networkTransport) Name() string {
+	return t.listener.String()
+}
+
+// Start implements Transport. The listener is bound before Start returns; later serve
+// failures are reported through Errors() and Wait().
+func (t *networkTransport) Start(context.Context, *server.MCPServer) error {
+	m := t.m
+	l := t.listener
+
+	ln, err := l.bind()
+	if err != nil {
+		return err
+	}
+
+	tlsConfig := m.tlsConfigFor(l)
+	httpSrv := &http.Server{Addr: l.Listen, TLSConfig: tlsConfig}
+	httpSrv.Handler, t.shutdown = m.newHTTPTransport(l.Mode, m.validatorFor(l), httpSrv)
+
+	m.serveWg.Add(1)
+	go func() {
+		defer m.serveWg.Done()
+		m.logger.Infof("MCP server listening on %s", l)
+		var err error
+		if tlsConfig != nil {
+			err = httpSrv.ServeTLS(ln, "", "")
+		} else {
+			err = httpSrv.Serve(ln)
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			m.reportError(fmt.Errorf("%s: %w", l, err))
+		}
+	}()
+	return nil
+}
+
+// Shutdown implements Transport
+func (t *networkTransport) Shutdown(ctx context.Context) error {
+	if t.shutdown == nil {
+		return nil
+	}
+	return t.shutdown(ctx)
+}
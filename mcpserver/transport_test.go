@@ -0,0 +1,400 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// unixHTTPClient returns an HTTP client that connects to a Unix domain socket whatever the URL
+func unixHTTPClient(path string, tlsConfig *tls.Config) *http.Client {
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		},
+		TLSClientConfig: tlsConfig,
+	}}
+}
+
+// callWhoami initializes a streamable HTTP session and returns the result of the whoami tool
+func callWhoami(t *testing.T, url string, httpClient *http.Client, headers map[string]string) string {
+	t.Helper()
+	c, err := client.NewStreamableHttpClient(url,
+		transport.WithHTTPBasicClient(httpClient),
+		transport.WithHTTPHeaders(headers))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err = c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	if _, err = c.Initialize(ctx, initRequest); err != nil {
+		t.Fatalf("initialize on %s: %v", url, err)
+	}
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "whoami"
+	result, err := c.CallTool(ctx, request)
+	if err != nil {
+		t.Fatalf("whoami on %s: %v", url, err)
+	}
+	return result.Content[0].(mcp.TextContent).Text
+}
+
+func TestMultipleListeners(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	writeCertificate(t, ca.issue(t, 10, "localhost", false), certFile, keyFile, time.Now())
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plainSocket, tlsSocket := filepath.Join(dir, "plain.sock"), filepath.Join(dir, "tls.sock")
+	m, err := New(
+		WithTLS(certFile, keyFile),
+		WithBearerTokenAuth(scopedValidator),
+		WithTransports(
+			HTTPListenerFrom(ln),
+			UnixHTTPListener(plainSocket).WithoutAuth().WithoutTLS(),
+			UnixHTTPListener(tlsSocket).WithoutAuth(),
+		),
+		WithToolProviders([]mcptypes.ToolProvider{&listProvider{tools: []mcptypes.ToolDefinition{whoamiTool()}}}))
+	if err != nil {
+		_ = ln.Close()
+		t.Fatal(err)
+	}
+	if err = m.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = m.Stop() })
+
+	// Every listener serves the same tools with its own authentication and TLS settings
+	clientTLS := &tls.Config{RootCAs: ca.pool, ServerName: "localhost"}
+	tcpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLS}}
+	tests := []struct {
+		name       string
+		url        string
+		httpClient *http.Client
+		headers    map[string]string
+		want       string
+	}{
+		{"TCP with TLS and authentication", "https://" + ln.Addr().String() + "/mcp", tcpClient, map[string]string{"Authorization": "Bearer read"}, "alice"},
+		{"Unix socket without TLS or authentication", "http://unix/mcp", unixHTTPClient(plainSocket, nil), nil, "anonymous"},
+		{"Unix socket with TLS", "https://unix/mcp", unixHTTPClient(tlsSocket, clientTLS), nil, "anonymous"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := callWhoami(t, tt.url, tt.httpClient, tt.headers); got != tt.want {
+				t.Errorf("whoami = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// The TCP listener still requires a token
+	resp, err := tcpClient.Post("https://"+ln.Addr().String()+"/mcp", "application/json", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("request without a token on TCP: status %d, want 401", resp.StatusCode)
+	}
+
+	// Plain text is refused on the TLS socket
+	resp, err = unixHTTPClient(tlsSocket, nil).Get("http://unix/mcp")
+	if err == nil {
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("plain text request on the TLS socket: status %d", resp.StatusCode)
+		}
+	}
+
+	// Stop closes every listener
+	if err = m.Stop(); err != nil {
+		t.Fatalf("Stop() = %v", err)
+	}
+	if conn, err := net.Dial("tcp", ln.Addr().String()); err == nil {
+		_ = conn.Close()
+		t.Error("TCP listener still accepting after Stop")
+	}
+	for _, path := range []string{plainSocket, tlsSocket} {
+		if _, err = os.Lstat(path); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("socket %s not removed after Stop: %v", path, err)
+		}
+	}
+}
+
+func TestMountedHandlers(t *testing.T) {
+	m := newRegistryServer(t,
+		WithBasePath("/api"),
+		WithToolProviders([]mcptypes.ToolProvider{&listProvider{tools: []mcptypes.ToolDefinition{whoamiTool()}}}))
+	if m.StreamableEndpoint() != "/api/mcp" || m.SSEEndpoint() != "/api/sse" {
+		t.Fatalf("endpoints = %s and %s, want them under /api", m.StreamableEndpoint(), m.SSEEndpoint())
+	}
+
+	// The MCP endpoints share a caller-owned server with the caller's own routes
+	mux := http.NewServeMux()
+	mux.Handle(m.StreamableEndpoint(), m.StreamableHandler())
+	sse := m.SSEHandler()
+	mux.Handle(m.SSEEndpoint(), sse)
+	mux.Handle(m.MessageEndpoint(), sse)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	if got := callWhoami(t, ts.URL+m.StreamableEndpoint(), ts.Client(), nil); got != "anonymous" {
+		t.Errorf("whoami = %q", got)
+	}
+	resp, err := http.Get(ts.URL + "/healthz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if string(body) != "ok" {
+		t.Errorf("health check = %q", body)
+	}
+
+	// Stop ends the open SSE stream although the caller owns the http.Server
+	resp, err = http.Get(ts.URL + m.SSEEndpoint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	reader := bufio.NewReader(resp.Body)
+	if line, err := reader.ReadString('\n'); err != nil || !strings.HasPrefix(line, "event: endpoint") {
+		t.Fatalf("first SSE line = %q, %v", line, err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(io.Discard, reader)
+		done <- err
+	}()
+	if err = m.Stop(); err != nil {
+		t.Errorf("Stop() = %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("SSE stream still open after Stop")
+	}
+}
+
+func TestStartReportsBindErrors(t *testing.T) {
+	occupied, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = occupied.Close() }()
+
+	socket := filepath.Join(t.TempDir(), "mcp.sock")
+	m, err := New(WithTransports(UnixHTTPListener(socket), HTTPListener(occupied.Addr().String())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = m.Start(); err == nil || !strings.Contains(err.Error(), occupied.Addr().String()) {
+		t.Errorf("Start() = %v, want the address in use", err)
+	}
+
+	// The listener started before the failure is shut down again
+	if _, err = os.Lstat(socket); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("socket of the first listener left open: %v", err)
+	}
+}
+
+func TestServeFailureReported(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := New(WithTransports(HTTPListenerFrom(ln)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = m.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = m.Stop() })
+
+	// Closing the listener under the server makes it fail after Start has returned
+	_ = ln.Close()
+	select {
+	case err = <-m.Errors():
+		if !strings.Contains(err.Error(), ln.Addr().String()) {
+			t.Errorf("error = %v, want the listener's address", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no error reported")
+	}
+	if err = m.Wait(); err == nil {
+		t.Error("Wait() = nil after the listener failed")
+	}
+}
+
+func TestStopDrainTimeout(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	slow := mcptypes.ToolDefinition{
+		Name: "slow",
+		Handler: func(map[string]any) (string, error) {
+			close(started)
+			<-release
+			return "done", nil
+		},
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := New(
+		WithTransports(HTTPListenerFrom(ln)),
+		WithShutdownTimeout(100*time.Millisecond),
+		WithToolProviders([]mcptypes.ToolProvider{&listProvider{tools: []mcptypes.ToolDefinition{slow}}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = m.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := client.NewStreamableHttpClient("http://" + ln.Addr().String() + "/mcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c.Close() }()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err = c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	if _, err = c.Initialize(ctx, initRequest); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = "slow"
+		_, _ = c.CallTool(ctx, request)
+	}()
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("tool not called")
+	}
+
+	// The call in progress keeps its connection open past the shutdown timeout
+	start := time.Now()
+	if err = m.Stop(); err == nil {
+		t.Error("Stop() = nil with a request still in progress")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Stop() took %v with a timeout of 100ms", elapsed)
+	}
+}
+
+// loopbackTransport is a custom transport that records its lifecycle and lets the test pass
+// messages to the server directly
+type loopbackTransport struct {
+	name     string
+	fail     error // Returned by Start
+	srv      *server.MCPServer
+	ctx      context.Context
+	shutdown bool
+}
+
+func (l *loopbackTransport) Name() string {
+	return l.name
+}
+
+func (l *loopbackTransport) Start(ctx context.Context, srv *server.MCPServer) error {
+	if l.fail != nil {
+		return l.fail
+	}
+	l.ctx, l.srv = ctx, srv
+	return nil
+}
+
+func (l *loopbackTransport) Shutdown(context.Context) error {
+	l.shutdown = true
+	return nil
+}
+
+func TestCustomTransport(t *testing.T) {
+	loopback := &loopbackTransport{name: "loopback"}
+	m, err := New(
+		WithTransport(loopback),
+		WithToolProviders([]mcptypes.ToolProvider{&listProvider{tools: []mcptypes.ToolDefinition{whoamiTool()}}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = m.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Messages the transport passes on reach the shared registry
+	message := json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"whoami"}}`)
+	response, ok := loopback.srv.HandleMessage(loopback.ctx, message).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("response = %+v", response)
+	}
+	result := response.Result.(mcp.CallToolResult)
+	if text := result.Content[0].(mcp.TextContent).Text; text != "anonymous" {
+		t.Errorf("whoami = %q", text)
+	}
+
+	if err = m.Stop(); err != nil {
+		t.Errorf("Stop() = %v", err)
+	}
+	if !loopback.shutdown {
+		t.Error("transport not shut down by Stop")
+	}
+}
+
+func TestCustomTransportStartFailure(t *testing.T) {
+	first := &loopbackTransport{name: "first"}
+	m, err := New(WithTransport(first), WithTransport(&loopbackTransport{name: "broken", fail: errors.New("no queue")}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = m.Start(); err == nil || err.Error() != "broken: no queue" {
+		t.Errorf("Start() = %v, want the transport's name and error", err)
+	}
+	if !first.shutdown {
+		t.Error("transport started before the failure not shut down")
+	}
+}
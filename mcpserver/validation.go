@@ -0,0 +1,369 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// validationError describes a single value that failed validation
+type validationError struct {
+	Path       string `json:"path"`       // Location of the value, e.g. "items[2].name"
+	Constraint string `json:"constraint"` // JSON Schema keyword that failed, e.g. "minLength"
+	Message    string `json:"message"`
+}
+
+// Error implements the error interface
+func (e validationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// validationErrors is a list of validation failures
+type validationErrors []validationError
+
+// Error implements the error interface
+func (e validationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, ve := range e {
+		messages[i] = ve.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// patternCache holds compiled regular expressions keyed by pattern
+var patternCache sync.Map
+
+// uuidPattern matches RFC 4122 UUIDs
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// validateArguments checks tool arguments against the declared parameters
+func validateArguments(params []*mcptypes.Parameter, args map[string]any) error {
+	var errs validationErrors
+	for _, param := range params {
+		value, exists := args[param.Name]
+		if !exists {
+			if param.Required {
+				errs = append(errs, validationError{Path: param.Name, Constraint: "required", Message: "is required"})
+			}
+			continue
+		}
+		errs = append(errs, validateValue(param.Name, param, value)...)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validationErrorResult converts a validation failure to a structured tool error
+func validationErrorResult(err error) *mcp.CallToolResult {
+	var errs validationErrors
+	switch e := err.(type) {
+	case validationErrors:
+		errs = e
+	case validationError:
+		errs = validationErrors{e}
+	default:
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	result := mcp.NewToolResultError(fmt.Sprintf("invalid arguments: %s", errs.Error()))
+	result.StructuredContent = map[string]any{"errors": errs}
+	return result
+}
+
+// validateValue checks a single value against a parameter schema, recursing into arrays and objects
+func validateValue(path string, param *mcptypes.Parameter, value any) validationErrors {
+	var errs validationErrors
+	fail := func(constraint, format string, args ...any) {
+		errs = append(errs, validationError{Path: path, Constraint: constraint, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// Type must match before any other keyword applies
+	if !matchesType(param.Type, value) {
+		fail("type", "must be of type %s", param.Type)
+		return errs
+	}
+
+	// Enum works with any type
+	if len(param.Enum) > 0 && !inEnum(param.Enum, value) {
+		fail("enum", "must be one of %s", formatEnum(param.Enum))
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := utf8.RuneCountInString(v)
+		if param.MinLength != nil && length < *param.MinLength {
+			fail("minLength", "must be at least %d characters", *param.MinLength)
+		}
+		if param.MaxLength != nil && length > *param.MaxLength {
+			fail("maxLength", "must be at most %d characters", *param.MaxLength)
+		}
+		if param.Pattern != nil {
+			re, err := compilePattern(*param.Pattern)
+			if err != nil {
+				fail("pattern", "has an invalid pattern in its schema: %v", err)
+			} else if !re.MatchString(v) {
+				fail("pattern", "must match pattern %s", *param.Pattern)
+			}
+		}
+		if param.Format != nil && !matchesFormat(*param.Format, v) {
+			fail("format", "must be a valid %s", *param.Format)
+		}
+
+	case []any:
+		if param.MinItems != nil && len(v) < *param.MinItems {
+			fail("minItems", "must contain at least %d items", *param.MinItems)
+		}
+		if param.MaxItems != nil && len(v) > *param.MaxItems {
+			fail("maxItems", "must contain at most %d items", *param.MaxItems)
+		}
+		if param.UniqueItems != nil && *param.UniqueItems && !uniqueItems(v) {
+			fail("uniqueItems", "must not contain duplicate items")
+		}
+		if param.Items != nil {
+			for i, item := range v {
+				errs = append(errs, validateValue(fmt.Sprintf("%s[%d]", path, i), param.Items, item)...)
+			}
+		}
+
+	case map[string]any:
+		names := make([]string, 0, len(param.Properties))
+		for name := range param.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			prop := param.Properties[name]
+			propValue, exists := v[name]
+			if !exists {
+				if prop.Required {
+					errs = append(errs, validationError{Path: path + "." + name, Constraint: "required", Message: "is required"})
+				}
+				continue
+			}
+			errs = append(errs, validateValue(path+"."+name, prop, propValue)...)
+		}
+		if param.AdditionalProperties != nil && !*param.AdditionalProperties {
+			var extra []string
+			for name := range v {
+				if _, declared := param.Properties[name]; !declared {
+					extra = append(extra, name)
+				}
+			}
+			sort.Strings(extra)
+			for _, name := range extra {
+				errs = append(errs, validationError{Path: path + "." + name, Constraint: "additionalProperties", Message: "is not an allowed property"})
+			}
+		}
+
+	default:
+		if f, ok := toFloat(value); ok {
+			errs = append(errs, validateNumber(path, param, f)...)
+		}
+	}
+
+	return errs
+}
+
+// validateNumber checks numeric constraints
+func validateNumber(path string, param *mcptypes.Parameter, value float64) validationErrors {
+	var errs validationErrors
+	fail := func(constraint, format string, args ...any) {
+		errs = append(errs, validationError{Path: path, Constraint: constraint, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if param.Minimum != nil {
+		if param.ExclusiveMinimum != nil && *param.ExclusiveMinimum {
+			if value <= *param.Minimum {
+				fail("exclusiveMinimum", "must be greater than %v", *param.Minimum)
+			}
+		} else if value < *param.Minimum {
+			fail("minimum", "must be at least %v", *param.Minimum)
+		}
+	}
+	if param.Maximum != nil {
+		if param.ExclusiveMaximum != nil && *param.ExclusiveMaximum {
+			if value >= *param.Maximum {
+				fail("exclusiveMaximum", "must be less than %v", *param.Maximum)
+			}
+		} else if value > *param.Maximum {
+			fail("maximum", "must be at most %v", *param.Maximum)
+		}
+	}
+	if param.MultipleOf != nil && *param.MultipleOf > 0 {
+		quotient := value / *param.MultipleOf
+		if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			fail("multipleOf", "must be a multiple of %v", *param.MultipleOf)
+		}
+	}
+	return errs
+}
+
+// matchesType reports whether a value matches a JSON Schema type
+func matchesType(schemaType string, value any) bool {
+	switch schemaType {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := toFloat(value)
+		return ok
+	case "integer":
+		f, ok := toFloat(value)
+		return ok && f == math.Trunc(f) && !math.IsInf(f, 0)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "null":
+		return value == nil
+	default:
+		return true
+	}
+}
+
+// toFloat converts any Go numeric value to float64
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// compilePattern compiles a regular expression, caching the result
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patternCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patternCache.Store(pattern, re)
+	return re, nil
+}
+
+// matchesFormat checks the well-known string formats. Unknown formats are treated as annotations.
+func matchesFormat(format, value string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, value)
+		return err == nil
+	case "date":
+		_, err := time.Parse(time.DateOnly, value)
+		return err == nil
+	case "time":
+		_, err := time.Parse("15:04:05Z07:00", value)
+		return err == nil
+	case "email":
+		addr, err := mail.ParseAddress(value)
+		return err == nil && addr.Address == value
+	case "uri":
+		u, err := url.Parse(value)
+		return err == nil && u.Scheme != ""
+	case "uuid":
+		return uuidPattern.MatchString(value)
+	case "ipv4":
+		ip := net.ParseIP(value)
+		return ip != nil && ip.To4() != nil && !strings.Contains(value, ":")
+	case "ipv6":
+		ip := net.ParseIP(value)
+		return ip != nil && strings.Contains(value, ":")
+	default:
+		return true
+	}
+}
+
+// canonicalJSON returns the JSON encoding of a value for equality comparisons
+func canonicalJSON(value any) []byte {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return []byte(fmt.Sprintf("%#v", value))
+	}
+	return data
+}
+
+// inEnum reports whether a value equals one of the enum values
+func inEnum(enum []any, value any) bool {
+	encoded := canonicalJSON(value)
+	for _, e := range enum {
+		if bytes.Equal(canonicalJSON(e), encoded) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatEnum renders enum values for error messages
+func formatEnum(enum []any) string {
+	values := make([]string, len(enum))
+	for i, e := range enum {
+		values[i] = string(canonicalJSON(e))
+	}
+	return "[" + strings.Join(values, ", ") + "]"
+}
+
+// uniqueItems reports whether all items in an array are distinct
+func uniqueItems(items []any) bool {
+	seen := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		key := string(canonicalJSON(item))
+		if _, dup := seen[key]; dup {
+			return false
+		}
+		seen[key] = struct{}{}
+	}
+	return true
+}
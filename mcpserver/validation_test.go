@@ -0,0 +1,254 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+func TestValidateArguments(t *testing.T) {
+	address := mcptypes.ObjectParam("address", "Address", false, map[string]*mcptypes.Parameter{
+		"city": mcptypes.StringParam("city", "City", true),
+		"zip":  mcptypes.StringParam("zip", "Postal code", false).WithPattern(`^\d{5}$`),
+	}).WithAdditionalProperties(false)
+
+	tests := []struct {
+		name  string
+		param *mcptypes.Parameter
+		args  map[string]any
+		want  []validationError // Path and Constraint of each expected error, in order
+	}{
+		{
+			name:  "valid string",
+			param: mcptypes.StringParam("s", "", true),
+			args:  map[string]any{"s": "hello"},
+		},
+		{
+			name:  "missing required",
+			param: mcptypes.StringParam("s", "", true),
+			args:  map[string]any{},
+			want:  []validationError{{Path: "s", Constraint: "required"}},
+		},
+		{
+			name:  "missing optional",
+			param: mcptypes.StringParam("s", "", false),
+			args:  map[string]any{},
+		},
+		{
+			name:  "wrong type",
+			param: mcptypes.NumberParam("n", "", true),
+			args:  map[string]any{"n": "5"},
+			want:  []validationError{{Path: "n", Constraint: "type"}},
+		},
+		{
+			name:  "integer rejects fraction",
+			param: mcptypes.IntegerParam("i", "", true),
+			args:  map[string]any{"i": 1.5},
+			want:  []validationError{{Path: "i", Constraint: "type"}},
+		},
+		{
+			name:  "integer accepts whole float",
+			param: mcptypes.IntegerParam("i", "", true),
+			args:  map[string]any{"i": 2.0},
+		},
+		{
+			name:  "enum match",
+			param: mcptypes.StringParam("e", "", true).WithEnum("a", "b"),
+			args:  map[string]any{"e": "b"},
+		},
+		{
+			name:  "enum mismatch",
+			param: mcptypes.StringParam("e", "", true).WithEnum("a", "b"),
+			args:  map[string]any{"e": "c"},
+			want:  []validationError{{Path: "e", Constraint: "enum"}},
+		},
+		{
+			name:  "numeric enum",
+			param: mcptypes.IntegerParam("e", "", true).WithEnum(1, 2),
+			args:  map[string]any{"e": 2.0},
+		},
+		{
+			name:  "string length",
+			param: mcptypes.StringParam("s", "", true).WithMinLength(2).WithMaxLength(3),
+			args:  map[string]any{"s": "a"},
+			want:  []validationError{{Path: "s", Constraint: "minLength"}},
+		},
+		{
+			name:  "length counts runes",
+			param: mcptypes.StringParam("s", "", true).WithMaxLength(3),
+			args:  map[string]any{"s": "héé"},
+		},
+		{
+			name:  "pattern",
+			param: mcptypes.StringParam("s", "", true).WithPattern(`^[a-z]+$`),
+			args:  map[string]any{"s": "ABC"},
+			want:  []validationError{{Path: "s", Constraint: "pattern"}},
+		},
+		{
+			name:  "minimum and maximum",
+			param: mcptypes.NumberParam("n", "", true).WithMinimum(1).WithMaximum(10),
+			args:  map[string]any{"n": 11.0},
+			want:  []validationError{{Path: "n", Constraint: "maximum"}},
+		},
+		{
+			name:  "inclusive bound",
+			param: mcptypes.NumberParam("n", "", true).WithMinimum(1),
+			args:  map[string]any{"n": 1.0},
+		},
+		{
+			name:  "exclusive minimum",
+			param: mcptypes.NumberParam("n", "", true).WithMinimum(1).WithExclusiveMinimum(true),
+			args:  map[string]any{"n": 1.0},
+			want:  []validationError{{Path: "n", Constraint: "exclusiveMinimum"}},
+		},
+		{
+			name:  "exclusive maximum",
+			param: mcptypes.NumberParam("n", "", true).WithMaximum(5).WithExclusiveMaximum(true),
+			args:  map[string]any{"n": 5.0},
+			want:  []validationError{{Path: "n", Constraint: "exclusiveMaximum"}},
+		},
+		{
+			name:  "multipleOf",
+			param: mcptypes.NumberParam("n", "", true).WithMultipleOf(0.5),
+			args:  map[string]any{"n": 1.25},
+			want:  []validationError{{Path: "n", Constraint: "multipleOf"}},
+		},
+		{
+			name:  "valid formats",
+			param: mcptypes.ObjectParam("f", "", true, formatParams()),
+			args: map[string]any{"f": map[string]any{
+				"date-time": "2025-01-02T03:04:05Z",
+				"date":      "2025-01-02",
+				"email":     "ada@example.com",
+				"uri":       "https://example.com/x",
+				"uuid":      "123e4567-e89b-12d3-a456-426614174000",
+				"ipv4":      "192.0.2.1",
+				"ipv6":      "2001:db8::1",
+			}},
+		},
+		{
+			name:  "invalid formats",
+			param: mcptypes.ObjectParam("f", "", true, formatParams()),
+			args: map[string]any{"f": map[string]any{
+				"date-time": "yesterday",
+				"date":      "2025-13-01",
+				"email":     "Ada <ada@example.com>",
+				"uri":       "example.com",
+				"uuid":      "123",
+				"ipv4":      "2001:db8::1",
+				"ipv6":      "192.0.2.1",
+			}},
+			want: []validationError{
+				{Path: "f.date", Constraint: "format"},
+				{Path: "f.date-time", Constraint: "format"},
+				{Path: "f.email", Constraint: "format"},
+				{Path: "f.ipv4", Constraint: "format"},
+				{Path: "f.ipv6", Constraint: "format"},
+				{Path: "f.uri", Constraint: "format"},
+				{Path: "f.uuid", Constraint: "format"},
+			},
+		},
+		{
+			name:  "unknown format is an annotation",
+			param: mcptypes.StringParam("s", "", true).WithFormat("color"),
+			args:  map[string]any{"s": "anything"},
+		},
+		{
+			name:  "array items",
+			param: mcptypes.ArrayParam("a", "", true, mcptypes.IntegerParam("", "", false).WithMinimum(0)),
+			args:  map[string]any{"a": []any{1.0, -1.0, "x"}},
+			want: []validationError{
+				{Path: "a[1]", Constraint: "minimum"},
+				{Path: "a[2]", Constraint: "type"},
+			},
+		},
+		{
+			name:  "array size and uniqueness",
+			param: mcptypes.ArrayParam("a", "", true, nil).WithMaxItems(2).WithUniqueItems(true),
+			args:  map[string]any{"a": []any{"x", "y", "x"}},
+			want: []validationError{
+				{Path: "a", Constraint: "maxItems"},
+				{Path: "a", Constraint: "uniqueItems"},
+			},
+		},
+		{
+			name:  "array minItems",
+			param: mcptypes.ArrayParam("a", "", true, nil).WithMinItems(1),
+			args:  map[string]any{"a": []any{}},
+			want:  []validationError{{Path: "a", Constraint: "minItems"}},
+		},
+		{
+			name:  "nested object",
+			param: address,
+			args:  map[string]any{"address": map[string]any{"zip": "K1A", "country": "CA"}},
+			want: []validationError{
+				{Path: "address.city", Constraint: "required"},
+				{Path: "address.zip", Constraint: "pattern"},
+				{Path: "address.country", Constraint: "additionalProperties"},
+			},
+		},
+		{
+			name: "objects in arrays",
+			param: mcptypes.ArrayParam("people", "", true, mcptypes.ObjectParam("", "", false, map[string]*mcptypes.Parameter{
+				"name": mcptypes.StringParam("name", "", true),
+				"age":  mcptypes.IntegerParam("age", "", false),
+			})),
+			args: map[string]any{"people": []any{
+				map[string]any{"name": "Ada", "age": 36.0},
+				map[string]any{"age": "old"},
+			}},
+			want: []validationError{
+				{Path: "people[1].age", Constraint: "type"},
+				{Path: "people[1].name", Constraint: "required"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateArguments([]*mcptypes.Parameter{tt.param}, tt.args)
+			var got validationErrors
+			if err != nil && !errors.As(err, &got) {
+				t.Fatalf("unexpected error type %T: %v", err, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d errors (%v), want %d", len(got), err, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if got[i].Path != want.Path || got[i].Constraint != want.Constraint {
+					t.Errorf("error %d = %s (%s), want %s (%s)", i, got[i].Path, got[i].Constraint, want.Path, want.Constraint)
+				}
+			}
+		})
+	}
+}
+
+// formatParams returns one string property per supported format, named after the format
+func formatParams() map[string]*mcptypes.Parameter {
+	params := make(map[string]*mcptypes.Parameter)
+	for _, format := range []string{"date-time", "date", "email", "uri", "uuid", "ipv4", "ipv6"} {
+		params[format] = mcptypes.StringParam(format, "", false).WithFormat(format)
+	}
+	return params
+}
+
+func TestValidationErrorResult(t *testing.T) {
+	err := validateArguments([]*mcptypes.Parameter{mcptypes.StringParam("s", "", true)}, map[string]any{})
+	result := validationErrorResult(err)
+	if !result.IsError {
+		t.Fatal("result is not an error")
+	}
+	structured, ok := result.StructuredContent.(map[string]any)
+	if !ok {
+		t.Fatalf("structured content is %T", result.StructuredContent)
+	}
+	if errs, ok := structured["errors"].(validationErrors); !ok || len(errs) != 1 || errs[0].Constraint != "required" {
+		t.Errorf("errors = %v", structured["errors"])
+	}
+}
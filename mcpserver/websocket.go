@@ -0,0 +1,250 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// WebSocket defaults
+const (
+	defaultWebSocketPingInterval   = 30 * time.Second
+	defaultWebSocketMaxMessageSize = 4 << 20
+	defaultWebSocketConcurrency    = 16
+)
+
+// webSocketTransport serves JSON-RPC messages over WebSocket connections, one MCP session per
+// connection. Connections are hijacked from the http.Server, so it closes them itself on shutdown.
+type webSocketTransport struct {
+	m *MCPServer
+
+	mu     sync.Mutex
+	conns  map[*wsConn]struct{}
+	closed bool
+}
+
+// newWebSocketTransport creates a WebSocket transport for the server
+func newWebSocketTransport(m *MCPServer) *webSocketTransport {
+	return &webSocketTransport{m: m, conns: make(map[*wsConn]struct{})}
+}
+
+// ServeHTTP implements http.Handler, upgrading requests to the WebSocket endpoint
+func (t *webSocketTransport) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != t.m.WebSocketEndpoint() {
+		http.NotFound(w, r)
+		return
+	}
+	if !allowedOrigin(r, t.m.wsOrigins) {
+		t.m.logger.Warningf("WebSocket upgrade rejected: origin %s is not allowed", r.Header.Get("Origin"))
+		http.Error(w, "Origin not allowed", http.StatusForbidden)
+		return
+	}
+
+	// Allow two missed pings before a silent connection is dropped (no limit if pings are disabled)
+	readTimeout := 2 * t.m.wsPingInterval
+	conn, err := upgradeWebSocket(w, r, t.m.wsMaxMessageSize, readTimeout)
+	if err != nil {
+		t.m.logger.Warningf("WebSocket upgrade failed: %v", err)
+		return
+	}
+
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		conn.closeWithStatus(wsCloseGoingAway, "server shutting down")
+		return
+	}
+	t.conns[conn] = struct{}{}
+	t.mu.Unlock()
+
+	defer func() {
+		t.mu.Lock()
+		delete(t.conns, conn)
+		t.mu.Unlock()
+	}()
+
+	t.serveConn(r.Context(), conn)
+}
+
+// serveConn runs an MCP session over a WebSocket connection until it is closed
+func (t *webSocketTransport) serveConn(ctx context.Context, conn *wsConn) {
+	ctx, cancel := context.WithCancel(withConnectionCredentials(ctx))
+	defer cancel()
+
+	session := newWebSocketSession()
+	if err := t.m.srv.RegisterSession(ctx, session); err != nil {
+		t.m.logger.Errorf("Unable to register WebSocket session: %v", err)
+		conn.closeWithStatus(wsCloseInternalError, "unable to register session")
+		return
+	}
+	defer t.m.srv.UnregisterSession(ctx, session.SessionID())
+	ctx = t.m.srv.WithContext(ctx, session)
+	t.m.logger.Debugf("WebSocket session %s connected", session.SessionID())
+
+	// Forward notifications and keep the connection alive until the session ends
+	go func() {
+		var pings <-chan time.Time
+		if t.m.wsPingInterval > 0 {
+			ticker := time.NewTicker(t.m.wsPingInterval)
+			defer ticker.Stop()
+			pings = ticker.C
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case notification := <-session.notifications:
+				t.send(conn, notification)
+			case <-pings:
+				if err := conn.writeFrame(wsOpPing, nil); err != nil {
+					conn.closeWithStatus(0, "")
+					return
+				}
+			}
+		}
+	}()
+
+	// Handle messages concurrently so a slow tool does not block the session, but no more than
+	// wsConcurrency at a time: further messages are not read until a handler finishes
+	slots := make(chan struct{}, max(t.m.wsConcurrency, 1))
+	for {
+		message, err := conn.readMessage()
+		if err != nil {
+			if !errors.Is(err, errWebSocketClosed) && !errors.Is(err, io.EOF) {
+				t.m.logger.Debugf("WebSocket session %s read error: %v", session.SessionID(), err)
+			}
+			conn.closeWithStatus(0, "")
+			break
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			conn.closeWithStatus(wsCloseGoingAway, "")
+			return
+		}
+		go func() {
+			defer func() { <-slots }()
+			if response := t.m.srv.HandleMessage(ctx, message); response != nil {
+				t.send(conn, response)
+			}
+		}()
+	}
+	t.m.logger.Debugf("WebSocket session %s disconnected", session.SessionID())
+}
+
+// send writes a JSON-RPC message to the connection
+func (t *webSocketTransport) send(conn *wsConn, message any) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		t.m.logger.Errorf("Unable to encode WebSocket message: %v", err)
+		return
+	}
+	if err = conn.writeMessage(data); err != nil {
+		t.m.logger.Debugf("WebSocket write failed: %v", err)
+	}
+}
+
+// shutdown closes all open connections with a going-away status
+func (t *webSocketTransport) shutdown(context.Context) error {
+	t.mu.Lock()
+	t.closed = true
+	conns := make([]*wsConn, 0, len(t.conns))
+	for conn := range t.conns {
+		conns = append(conns, conn)
+	}
+	t.mu.Unlock()
+
+	for _, conn := range conns {
+		conn.closeWithStatus(wsCloseGoingAway, "server shutting down")
+	}
+	return nil
+}
+
+// webSocketSession is the MCP client session of one WebSocket connection
+type webSocketSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+	initialized   atomic.Bool
+
+	mu                 sync.RWMutex
+	logLevel           mcp.LoggingLevel
+	clientInfo         mcp.Implementation
+	clientCapabilities mcp.ClientCapabilities
+}
+
+var (
+	_ server.SessionWithLogging    = (*webSocketSession)(nil)
+	_ server.SessionWithClientInfo = (*webSocketSession)(nil)
+)
+
+// newWebSocketSession creates a session with a random ID
+func newWebSocketSession() *webSocketSession {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return &webSocketSession{
+		id:            "ws-" + hex.EncodeToString(id),
+		notifications: make(chan mcp.JSONRPCNotification, 100),
+		logLevel:      mcp.LoggingLevelError,
+	}
+}
+
+func (s *webSocketSession) SessionID() string { return s.id }
+
+func (s *webSocketSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func (s *webSocketSession) Initialize() { s.initialized.Store(true) }
+
+func (s *webSocketSession) Initialized() bool { return s.initialized.Load() }
+
+func (s *webSocketSession) SetLogLevel(level mcp.LoggingLevel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logLevel = level
+}
+
+func (s *webSocketSession) GetLogLevel() mcp.LoggingLevel {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.logLevel
+}
+
+func (s *webSocketSession) GetClientInfo() mcp.Implementation {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.clientInfo
+}
+
+func (s *webSocketSession) SetClientInfo(clientInfo mcp.Implementation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clientInfo = clientInfo
+}
+
+func (s *webSocketSession) GetClientCapabilities() mcp.ClientCapabilities {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.clientCapabilities
+}
+
+func (s *webSocketSession) SetClientCapabilities(clientCapabilities mcp.ClientCapabilities) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clientCapabilities = clientCapabilities
+}
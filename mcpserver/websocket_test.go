@@ -0,0 +1,562 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcpserver"
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// WebSocket opcodes and close codes used by the tests (RFC 6455)
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+
+	closeNormal        = 1000
+	closeProtocolError = 1002
+	closeInvalidData   = 1007
+	closeMessageTooBig = 1009
+)
+
+// testWebSocketKey is the sample key from RFC 6455 section 1.3
+const testWebSocketKey = "dGhlIHNhbXBsZSBub25jZQ=="
+
+// wsClient is a minimal in-process WebSocket client that writes raw frames, so tests can also
+// send frames a conforming client never would
+type wsClient struct {
+	t      *testing.T
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// startWebSocketServer serves the WebSocket handler of a server configured with options
+func startWebSocketServer(t *testing.T, options ...mcpserver.Option) *httptest.Server {
+	t.Helper()
+	m, err := mcpserver.New(append([]mcpserver.Option{mcpserver.WithHandlerOnly()}, options...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(m.WebSocketHandler())
+	t.Cleanup(func() {
+		_ = m.Stop()
+		ts.Close()
+	})
+	return ts
+}
+
+// handshake sends an upgrade request with the standard headers, changed by header (an empty
+// value removes a header), and returns the response and the connection
+func handshake(t *testing.T, ts *httptest.Server, method string, header http.Header) (*http.Response, *wsClient) {
+	t.Helper()
+	conn, err := net.Dial("tcp", ts.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	req, err := http.NewRequest(method, ts.URL+"/ws", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", testWebSocketKey)
+	for name, values := range header {
+		req.Header.Del(name)
+		for _, value := range values {
+			if value != "" {
+				req.Header.Add(name, value)
+			}
+		}
+	}
+	if err = req.Write(conn); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, &wsClient{t: t, conn: conn, reader: reader}
+}
+
+// dialWebSocket completes a handshake that must succeed
+func dialWebSocket(t *testing.T, ts *httptest.Server) *wsClient {
+	t.Helper()
+	resp, c := handshake(t, ts, http.MethodGet, nil)
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("handshake status = %d", resp.StatusCode)
+	}
+	return c
+}
+
+// writeFrame sends one frame, masked as clients must unless masked is false
+func (c *wsClient) writeFrame(fin bool, opcode byte, payload []byte, masked bool) {
+	c.t.Helper()
+	first := opcode
+	if fin {
+		first |= 0x80
+	}
+	frame := []byte{first}
+	maskBit := byte(0)
+	if masked {
+		maskBit = 0x80
+	}
+	switch {
+	case len(payload) <= 125:
+		frame = append(frame, maskBit|byte(len(payload)))
+	case len(payload) <= 0xffff:
+		frame = append(frame, maskBit|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(len(payload)))
+	default:
+		frame = append(frame, maskBit|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(len(payload)))
+	}
+	if masked {
+		mask := []byte{0x12, 0x34, 0x56, 0x78}
+		frame = append(frame, mask...)
+		for i, b := range payload {
+			frame = append(frame, b^mask[i%4])
+		}
+	} else {
+		frame = append(frame, payload...)
+	}
+	if _, err := c.conn.Write(frame); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// readFrame reads one unmasked server frame
+func (c *wsClient) readFrame() (byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return 0, nil, err
+	}
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return 0, nil, err
+	}
+	return header[0] & 0x0f, payload, nil
+}
+
+// expectFrame reads the next frame and checks its opcode
+func (c *wsClient) expectFrame(opcode byte) []byte {
+	c.t.Helper()
+	got, payload, err := c.readFrame()
+	if err != nil {
+		c.t.Fatalf("reading frame: %v", err)
+	}
+	if got != opcode {
+		c.t.Fatalf("opcode = %#x (%q), want %#x", got, payload, opcode)
+	}
+	return payload
+}
+
+// expectClose reads a close frame with the given status code, after which the server must
+// close the connection
+func (c *wsClient) expectClose(code int) {
+	c.t.Helper()
+	payload := c.expectFrame(opClose)
+	if len(payload) < 2 || int(binary.BigEndian.Uint16(payload)) != code {
+		c.t.Fatalf("close payload = %v, want status %d", payload, code)
+	}
+	if _, _, err := c.readFrame(); !errors.Is(err, io.EOF) {
+		c.t.Fatalf("connection still open after close: %v", err)
+	}
+}
+
+func TestWebSocketHandshake(t *testing.T) {
+	accept := sha1.Sum([]byte(testWebSocketKey + "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"))
+	validate := func(token string) (map[string]any, error) {
+		if token == "secret" {
+			return map[string]any{"sub": "user"}, nil
+		}
+		return nil, errors.New("invalid token")
+	}
+
+	tests := []struct {
+		name     string
+		options  []mcpserver.Option
+		method   string
+		header   http.Header
+		status   int
+		protocol string
+	}{
+		{name: "valid", status: http.StatusSwitchingProtocols},
+		{name: "subprotocol", header: http.Header{"Sec-Websocket-Protocol": {"mcp"}}, status: http.StatusSwitchingProtocols, protocol: "mcp"},
+		{name: "other subprotocol not echoed", header: http.Header{"Sec-Websocket-Protocol": {"chat"}}, status: http.StatusSwitchingProtocols},
+		{name: "not GET", method: http.MethodPost, status: http.StatusMethodNotAllowed},
+		{name: "no upgrade", header: http.Header{"Upgrade": {""}}, status: http.StatusUpgradeRequired},
+		{name: "old version", header: http.Header{"Sec-Websocket-Version": {"8"}}, status: http.StatusUpgradeRequired},
+		{name: "invalid key", header: http.Header{"Sec-Websocket-Key": {"short"}}, status: http.StatusBadRequest},
+		{name: "foreign origin", header: http.Header{"Origin": {"https://evil.example"}}, status: http.StatusForbidden},
+		{name: "null origin", header: http.Header{"Origin": {"null"}}, status: http.StatusForbidden},
+		{
+			name:    "allowed origin",
+			options: []mcpserver.Option{mcpserver.WithWebSocketOrigins("https://app.example.com")},
+			header:  http.Header{"Origin": {"https://app.example.com"}},
+			status:  http.StatusSwitchingProtocols,
+		},
+		{
+			name:    "origin not in list",
+			options: []mcpserver.Option{mcpserver.WithWebSocketOrigins("https://app.example.com")},
+			header:  http.Header{"Origin": {"https://app.example.com.evil.example"}},
+			status:  http.StatusForbidden,
+		},
+		{
+			name:    "any origin",
+			options: []mcpserver.Option{mcpserver.WithWebSocketOrigins("*")},
+			header:  http.Header{"Origin": {"https://evil.example"}},
+			status:  http.StatusSwitchingProtocols,
+		},
+		{
+			name:    "missing token",
+			options: []mcpserver.Option{mcpserver.WithBearerTokenAuth(validate)},
+			status:  http.StatusUnauthorized,
+		},
+		{
+			name:    "authorization header",
+			options: []mcpserver.Option{mcpserver.WithBearerTokenAuth(validate)},
+			header:  http.Header{"Authorization": {"Bearer secret"}},
+			status:  http.StatusSwitchingProtocols,
+		},
+		{
+			name:     "browser token subprotocol",
+			options:  []mcpserver.Option{mcpserver.WithBearerTokenAuth(validate)},
+			header:   http.Header{"Sec-Websocket-Protocol": {"mcp, bearer.secret"}},
+			status:   http.StatusSwitchingProtocols,
+			protocol: "mcp",
+		},
+		{
+			name:    "invalid browser token",
+			options: []mcpserver.Option{mcpserver.WithBearerTokenAuth(validate)},
+			header:  http.Header{"Sec-Websocket-Protocol": {"mcp, bearer.wrong"}},
+			status:  http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := startWebSocketServer(t, tt.options...)
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			resp, _ := handshake(t, ts, method, tt.header)
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if tt.status != http.StatusSwitchingProtocols {
+				return
+			}
+			if got := resp.Header.Get("Sec-WebSocket-Accept"); got != base64.StdEncoding.EncodeToString(accept[:]) {
+				t.Errorf("Sec-WebSocket-Accept = %q", got)
+			}
+			if got := resp.Header.Get("Sec-WebSocket-Protocol"); got != tt.protocol {
+				t.Errorf("Sec-WebSocket-Protocol = %q, want %q", got, tt.protocol)
+			}
+		})
+	}
+}
+
+func TestWebSocketSameOrigin(t *testing.T) {
+	ts := startWebSocketServer(t)
+	resp, _ := handshake(t, ts, http.MethodGet, http.Header{"Origin": {ts.URL}})
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("status = %d for the server's own origin", resp.StatusCode)
+	}
+}
+
+func TestWebSocketMessages(t *testing.T) {
+	ping := []byte(`{"jsonrpc":"2.0","id":7,"method":"ping"}`)
+
+	tests := []struct {
+		name   string
+		frames func(c *wsClient)
+	}{
+		{"single frame", func(c *wsClient) {
+			c.writeFrame(true, opText, ping, true)
+		}},
+		{"fragmented", func(c *wsClient) {
+			c.writeFrame(false, opText, ping[:10], true)
+			c.writeFrame(false, opContinuation, ping[10:20], true)
+			c.writeFrame(true, opContinuation, ping[20:], true)
+		}},
+		{"control frame between fragments", func(c *wsClient) {
+			c.writeFrame(false, opText, ping[:10], true)
+			c.writeFrame(true, opPing, []byte("mid"), true)
+			if payload := c.expectFrame(opPong); string(payload) != "mid" {
+				c.t.Fatalf("pong payload = %q", payload)
+			}
+			c.writeFrame(true, opContinuation, ping[10:], true)
+		}},
+		{"extended length", func(c *wsClient) {
+			padded := append(bytes.Repeat([]byte(" "), 200), ping...)
+			c.writeFrame(true, opText, padded, true)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := dialWebSocket(t, startWebSocketServer(t))
+			tt.frames(c)
+
+			var response struct {
+				ID     int            `json:"id"`
+				Result map[string]any `json:"result"`
+			}
+			if err := json.Unmarshal(c.expectFrame(opText), &response); err != nil {
+				t.Fatal(err)
+			}
+			if response.ID != 7 || response.Result == nil {
+				t.Errorf("unexpected response %+v", response)
+			}
+		})
+	}
+}
+
+func TestWebSocketProtocolErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []mcpserver.Option
+		frames  func(c *wsClient)
+		code    int
+	}{
+		{"unmasked frame", nil, func(c *wsClient) {
+			c.writeFrame(true, opText, []byte(`{}`), false)
+		}, closeProtocolError},
+		{"reserved bits", nil, func(c *wsClient) {
+			c.writeFrame(true, opText|0x40, []byte(`{}`), true)
+		}, closeProtocolError},
+		{"unknown opcode", nil, func(c *wsClient) {
+			c.writeFrame(true, 0x3, []byte(`{}`), true)
+		}, closeProtocolError},
+		{"continuation without message", nil, func(c *wsClient) {
+			c.writeFrame(true, opContinuation, []byte(`{}`), true)
+		}, closeProtocolError},
+		{"new message during fragments", nil, func(c *wsClient) {
+			c.writeFrame(false, opText, []byte(`{`), true)
+			c.writeFrame(true, opText, []byte(`}`), true)
+		}, closeProtocolError},
+		{"fragmented control frame", nil, func(c *wsClient) {
+			c.writeFrame(false, opPing, nil, true)
+		}, closeProtocolError},
+		{"oversized control frame", nil, func(c *wsClient) {
+			c.writeFrame(true, opPing, bytes.Repeat([]byte("x"), 126), true)
+		}, closeProtocolError},
+		{"invalid UTF-8", nil, func(c *wsClient) {
+			c.writeFrame(true, opText, []byte{0xff, 0xfe}, true)
+		}, closeInvalidData},
+		{"message too big", []mcpserver.Option{mcpserver.WithWebSocketMaxMessageSize(64)}, func(c *wsClient) {
+			c.writeFrame(true, opText, bytes.Repeat([]byte(" "), 65), true)
+		}, closeMessageTooBig},
+		{"fragments too big", []mcpserver.Option{mcpserver.WithWebSocketMaxMessageSize(64)}, func(c *wsClient) {
+			c.writeFrame(false, opText, bytes.Repeat([]byte(" "), 40), true)
+			c.writeFrame(true, opContinuation, bytes.Repeat([]byte(" "), 40), true)
+		}, closeMessageTooBig},
+		{"client close", nil, func(c *wsClient) {
+			c.writeFrame(true, opClose, binary.BigEndian.AppendUint16(nil, closeNormal), true)
+		}, closeNormal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := dialWebSocket(t, startWebSocketServer(t, tt.options...))
+			tt.frames(c)
+			c.expectClose(tt.code)
+		})
+	}
+}
+
+func TestWebSocketPing(t *testing.T) {
+	// The client's pings are answered with its payload
+	c := dialWebSocket(t, startWebSocketServer(t))
+	c.writeFrame(true, opPing, []byte("hello"), true)
+	if payload := c.expectFrame(opPong); string(payload) != "hello" {
+		t.Errorf("pong payload = %q", payload)
+	}
+
+	// The server pings on its interval, and drops a client that stays silent for two intervals
+	c = dialWebSocket(t, startWebSocketServer(t, mcpserver.WithWebSocketPingInterval(50*time.Millisecond)))
+	c.expectFrame(opPing)
+	for {
+		opcode, _, err := c.readFrame()
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !strings.Contains(err.Error(), "reset") {
+				t.Fatalf("unexpected read error: %v", err)
+			}
+			break
+		}
+		if opcode != opPing && opcode != opClose {
+			t.Fatalf("unexpected opcode %#x", opcode)
+		}
+	}
+}
+
+// blockingProvider offers a tool that reports each call and waits until the gate is closed
+type blockingProvider struct {
+	started chan struct{}
+	gate    chan struct{}
+}
+
+func (p blockingProvider) RegisterTools() []mcptypes.ToolDefinition {
+	return []mcptypes.ToolDefinition{{
+		Name: "wait",
+		Handler: func(map[string]any) (string, error) {
+			p.started <- struct{}{}
+			<-p.gate
+			return "done", nil
+		},
+	}}
+}
+
+func TestWebSocketConcurrencyLimit(t *testing.T) {
+	const limit, calls = 2, 5
+	p := blockingProvider{started: make(chan struct{}, calls), gate: make(chan struct{})}
+	c := dialWebSocket(t, startWebSocketServer(t,
+		mcpserver.WithWebSocketConcurrency(limit),
+		mcpserver.WithToolProviders([]mcptypes.ToolProvider{p}),
+	))
+
+	for id := 1; id <= calls; id++ {
+		call := fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"tools/call","params":{"name":"wait"}}`, id)
+		c.writeFrame(true, opText, []byte(call), true)
+	}
+
+	// Only limit calls start while they are blocked
+	for range limit {
+		select {
+		case <-p.started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("calls did not start")
+		}
+	}
+	select {
+	case <-p.started:
+		t.Fatalf("more than %d calls ran at once", limit)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Once released, every call completes
+	close(p.gate)
+	for range calls {
+		c.expectFrame(opText)
+	}
+}
+
+func TestWebSocketConnectionCredentials(t *testing.T) {
+	// Each validation returns a claim of its own, and the token can be revoked
+	var validations atomic.Int32
+	var revoked atomic.Bool
+	validate := func(token string) (map[string]any, error) {
+		if token != "secret" || revoked.Load() {
+			return nil, errors.New("invalid token")
+		}
+		n := validations.Add(1)
+		return map[string]any{"sub": "alice", fmt.Sprintf("validation%d", n): true}, nil
+	}
+	whoami := mcptypes.ToolDefinition{
+		Name: "whoami",
+		HandlerContext: func(ctx context.Context, _ mcptypes.ToolRequest, _ map[string]any) (string, error) {
+			info, ok := mcpserver.AuthFromContext(ctx)
+			if !ok {
+				return "anonymous", nil
+			}
+			return fmt.Sprintf("%s %d", info.Subject, len(info.Claims)), nil
+		},
+	}
+
+	tests := []struct {
+		name   string
+		header http.Header
+	}{
+		{"authorization header", http.Header{"Authorization": {"Bearer secret"}}},
+		{"browser token subprotocol", http.Header{"Sec-Websocket-Protocol": {"mcp, bearer.secret"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revoked.Store(false)
+			ts := startWebSocketServer(t,
+				mcpserver.WithBearerTokenAuth(validate),
+				mcpserver.WithToolProviders([]mcptypes.ToolProvider{listTools{whoami}}))
+			resp, c := handshake(t, ts, http.MethodGet, tt.header)
+			if resp.StatusCode != http.StatusSwitchingProtocols {
+				t.Fatalf("handshake status = %d", resp.StatusCode)
+			}
+
+			call := func(id int) map[string]any {
+				request := fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"tools/call","params":{"name":"whoami"}}`, id)
+				c.writeFrame(true, opText, []byte(request), true)
+				var response map[string]any
+				if err := json.Unmarshal(c.expectFrame(opText), &response); err != nil {
+					t.Fatal(err)
+				}
+				return response
+			}
+
+			// Each call validates the connection's token again, and sees only the claims of
+			// that validation
+			for id := 1; id <= 2; id++ {
+				result, _ := call(id)["result"].(map[string]any)
+				content, _ := result["content"].([]any)
+				if len(content) != 1 {
+					t.Fatalf("call %d result = %v", id, result)
+				}
+				if text := content[0].(map[string]any)["text"]; text != "alice 2" {
+					t.Errorf("call %d: whoami = %v, want alice with 2 claims", id, text)
+				}
+			}
+
+			// Once the token is revoked, calls on the open connection are rejected
+			revoked.Store(true)
+			response := call(3)
+			result, _ := response["result"].(map[string]any)
+			if response["error"] == nil && result["isError"] != true {
+				t.Errorf("call accepted after the token was revoked: %v", response)
+			}
+		})
+	}
+}
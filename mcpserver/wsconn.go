@@ -0,0 +1,371 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+// This file implements the server side of the WebSocket protocol (RFC 6455) needed by the
+// WebSocket transport: the opening handshake, framing with fragmentation, ping/pong and
+// the closing handshake. Extensions such as compression are not negotiated.
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// webSocketGUID is appended to the client key to compute Sec-WebSocket-Accept
+const webSocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// webSocketSubprotocol is the subprotocol echoed back when offered by the client
+const webSocketSubprotocol = "mcp"
+
+// webSocketTokenPrefix marks the subprotocol in which a browser client, which cannot set an
+// Authorization header on a WebSocket, offers its bearer token: "bearer.<token>". It is
+// offered alongside "mcp", which is the subprotocol echoed back.
+const webSocketTokenPrefix = "bearer."
+
+// webSocketWriteTimeout bounds how long a single frame write may block
+const webSocketWriteTimeout = 10 * time.Second
+
+// WebSocket opcodes
+const (
+	wsOpContinuation = 0x0
+	wsOpText         = 0x1
+	wsOpBinary       = 0x2
+	wsOpClose        = 0x8
+	wsOpPing         = 0x9
+	wsOpPong         = 0xA
+)
+
+// WebSocket close status codes
+const (
+	wsCloseNormal        = 1000
+	wsCloseGoingAway     = 1001
+	wsCloseProtocolError = 1002
+	wsCloseInvalidData   = 1007
+	wsCloseMessageTooBig = 1009
+	wsCloseInternalError = 1011
+)
+
+// errWebSocketClosed is returned by readMessage when the peer completed the closing handshake
+var errWebSocketClosed = errors.New("websocket closed")
+
+// wsCloseError is a protocol violation that closes the connection with the given status code
+type wsCloseError struct {
+	code   int
+	reason string
+}
+
+func (e *wsCloseError) Error() string {
+	return fmt.Sprintf("websocket error %d: %s", e.code, e.reason)
+}
+
+// wsConn is a server-side WebSocket connection. Reads must come from a single goroutine;
+// writes are safe for concurrent use.
+type wsConn struct {
+	conn           net.Conn
+	reader         *bufio.Reader
+	maxMessageSize int64
+	readTimeout    time.Duration
+
+	writeMu   sync.Mutex
+	closeOnce sync.Once
+}
+
+// upgradeWebSocket performs the opening handshake and takes over the connection.
+// On failure an HTTP error response has been written.
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request, maxMessageSize int64, readTimeout time.Duration) (*wsConn, error) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "WebSocket upgrade requires GET", http.StatusMethodNotAllowed)
+		return nil, fmt.Errorf("invalid method %s", r.Method)
+	}
+	if !headerHasToken(r.Header, "Connection", "upgrade") || !headerHasToken(r.Header, "Upgrade", "websocket") {
+		http.Error(w, "WebSocket upgrade required", http.StatusUpgradeRequired)
+		return nil, fmt.Errorf("missing upgrade headers")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "Unsupported WebSocket version", http.StatusUpgradeRequired)
+		return nil, fmt.Errorf("unsupported version %q", r.Header.Get("Sec-WebSocket-Version"))
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		http.Error(w, "Invalid Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, fmt.Errorf("invalid key")
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "WebSocket not supported by this server", http.StatusInternalServerError)
+		return nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("hijack failed: %w", err)
+	}
+
+	// Complete the handshake
+	accept := sha1.Sum([]byte(key + webSocketGUID))
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(accept[:]) + "\r\n"
+	if headerHasToken(r.Header, "Sec-WebSocket-Protocol", webSocketSubprotocol) {
+		response += "Sec-WebSocket-Protocol: " + webSocketSubprotocol + "\r\n"
+	}
+	response += "\r\n"
+
+	_ = conn.SetDeadline(time.Now().Add(webSocketWriteTimeout))
+	if _, err = rw.WriteString(response); err == nil {
+		err = rw.Flush()
+	}
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("handshake failed: %w", err)
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	if maxMessageSize <= 0 {
+		maxMessageSize = defaultWebSocketMaxMessageSize
+	}
+	return &wsConn{
+		conn:           conn,
+		reader:         rw.Reader,
+		maxMessageSize: maxMessageSize,
+		readTimeout:    readTimeout,
+	}, nil
+}
+
+// headerHasToken reports whether a comma-separated header contains the token (case-insensitive)
+func headerHasToken(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// webSocketToken returns the bearer token offered as a subprotocol of a WebSocket upgrade
+// request, or "" if there is none
+func webSocketToken(r *http.Request) string {
+	if !headerHasToken(r.Header, "Upgrade", "websocket") {
+		return ""
+	}
+	for _, value := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for _, part := range strings.Split(value, ",") {
+			if token, ok := strings.CutPrefix(strings.TrimSpace(part), webSocketTokenPrefix); ok {
+				return token
+			}
+		}
+	}
+	return ""
+}
+
+// allowedOrigin reports whether a WebSocket upgrade request may be accepted: it has no Origin
+// header, its origin is the server's own host, or the origin is in the allowed list
+func allowedOrigin(r *http.Request, allowed []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, entry := range allowed {
+		if entry == "*" || strings.EqualFold(strings.TrimSuffix(entry, "/"), origin) {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host != "" && strings.EqualFold(u.Host, r.Host)
+}
+
+// readMessage returns the next complete text or binary message, answering pings and
+// close frames along the way. Protocol violations close the connection.
+func (c *wsConn) readMessage() ([]byte, error) {
+	var message []byte
+	var opcode byte
+	for {
+		fin, frameOpcode, payload, err := c.readFrame(int64(len(message)))
+		if err != nil {
+			var closeErr *wsCloseError
+			if errors.As(err, &closeErr) {
+				c.closeWithStatus(closeErr.code, closeErr.reason)
+			}
+			return nil, err
+		}
+
+		switch frameOpcode {
+		case wsOpPing:
+			if err = c.writeFrame(wsOpPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case wsOpPong:
+			continue
+		case wsOpClose:
+			// Echo the status code, if any, to complete the closing handshake
+			if len(payload) >= 2 {
+				c.closeWithStatus(int(binary.BigEndian.Uint16(payload)), "")
+			} else {
+				c.closeWithStatus(0, "")
+			}
+			return nil, errWebSocketClosed
+		case wsOpText, wsOpBinary:
+			if opcode != 0 {
+				c.closeWithStatus(wsCloseProtocolError, "expected continuation frame")
+				return nil, fmt.Errorf("unexpected data frame during fragmented message")
+			}
+			opcode = frameOpcode
+		case wsOpContinuation:
+			if opcode == 0 {
+				c.closeWithStatus(wsCloseProtocolError, "unexpected continuation frame")
+				return nil, fmt.Errorf("continuation frame without a message")
+			}
+		default:
+			c.closeWithStatus(wsCloseProtocolError, "unknown opcode")
+			return nil, fmt.Errorf("unknown opcode %d", frameOpcode)
+		}
+
+		message = append(message, payload...)
+		if !fin {
+			continue
+		}
+		if opcode == wsOpText && !utf8.Valid(message) {
+			c.closeWithStatus(wsCloseInvalidData, "invalid UTF-8")
+			return nil, fmt.Errorf("invalid UTF-8 in text message")
+		}
+		return message, nil
+	}
+}
+
+// readFrame reads one frame. buffered is the size of the message assembled so far,
+// used to enforce the message size limit across fragments.
+func (c *wsConn) readFrame(buffered int64) (bool, byte, []byte, error) {
+	if c.readTimeout > 0 {
+		_ = c.conn.SetReadDeadline(time.Now().Add(c.readTimeout))
+	}
+
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin := header[0]&0x80 != 0
+	opcode := header[0] & 0x0f
+	if header[0]&0x70 != 0 {
+		return false, 0, nil, &wsCloseError{wsCloseProtocolError, "reserved bits set"}
+	}
+	if header[1]&0x80 == 0 {
+		return false, 0, nil, &wsCloseError{wsCloseProtocolError, "client frames must be masked"}
+	}
+
+	length := int64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = int64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		if ext[0]&0x80 != 0 {
+			return false, 0, nil, &wsCloseError{wsCloseProtocolError, "invalid frame length"}
+		}
+		length = int64(binary.BigEndian.Uint64(ext[:]))
+	}
+
+	// The length is checked before the payload is allocated, so a peer cannot make the
+	// server allocate more than the message size limit
+	if opcode >= wsOpClose {
+		if !fin || length > 125 {
+			return false, 0, nil, &wsCloseError{wsCloseProtocolError, "invalid control frame"}
+		}
+	} else if length > c.maxMessageSize-buffered {
+		return false, 0, nil, &wsCloseError{wsCloseMessageTooBig, "message too big"}
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return fin, opcode, payload, nil
+}
+
+// writeMessage sends a text message in a single frame
+func (c *wsConn) writeMessage(data []byte) error {
+	return c.writeFrame(wsOpText, data)
+}
+
+// writeFrame sends a single unmasked frame
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	header := make([]byte, 0, 10)
+	header = append(header, 0x80|opcode)
+	switch {
+	case len(payload) <= 125:
+		header = append(header, byte(len(payload)))
+	case len(payload) <= 0xffff:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(len(payload)))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(len(payload)))
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_ = c.conn.SetWriteDeadline(time.Now().Add(webSocketWriteTimeout))
+	if _, err := c.conn.Write(header); err != nil {
+		return err
+	}
+	_, err := c.conn.Write(payload)
+	return err
+}
+
+// truncateUTF8 shortens s to at most n bytes without splitting a multi-byte character
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// closeWithStatus sends a close frame (unless code is 0) and closes the connection
+func (c *wsConn) closeWithStatus(code int, reason string) {
+	c.closeOnce.Do(func() {
+		if code != 0 {
+			payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+			reason = truncateUTF8(reason, 123)
+			_ = c.writeFrame(wsOpClose, append(payload, reason...))
+		} else {
+			_ = c.writeFrame(wsOpClose, nil)
+		}
+		_ = c.conn.Close()
+	})
+}
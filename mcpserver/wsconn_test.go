@@ -0,0 +1,125 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// discardConn is a net.Conn that accepts and records writes, for reading frames from a buffer
+type discardConn struct {
+	net.Conn
+	written bytes.Buffer
+}
+
+func (c *discardConn) Write(p []byte) (int, error)      { return c.written.Write(p) }
+func (c *discardConn) Close() error                     { return nil }
+func (c *discardConn) SetReadDeadline(time.Time) error  { return nil }
+func (c *discardConn) SetWriteDeadline(time.Time) error { return nil }
+
+// newTestWSConn returns a connection that reads the given bytes
+func newTestWSConn(data []byte, maxMessageSize int64) (*wsConn, *discardConn) {
+	conn := &discardConn{}
+	return &wsConn{conn: conn, reader: bufio.NewReader(bytes.NewReader(data)), maxMessageSize: maxMessageSize}, conn
+}
+
+// maskedFrame encodes a client frame with a zero mask and the given declared payload length
+func maskedFrame(header byte, length uint64, payload []byte) []byte {
+	frame := []byte{header}
+	switch {
+	case length <= 125:
+		frame = append(frame, 0x80|byte(length))
+	case length <= 0xffff:
+		frame = append(frame, 0x80|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(length))
+	default:
+		frame = append(frame, 0x80|127)
+		frame = binary.BigEndian.AppendUint64(frame, length)
+	}
+	frame = append(frame, 0, 0, 0, 0)
+	return append(frame, payload...)
+}
+
+func TestReadFrameChecksLengthBeforeAllocating(t *testing.T) {
+	tests := []struct {
+		name   string
+		frame  []byte
+		buffer int64
+	}{
+		{"64-bit length", maskedFrame(0x80|wsOpText, 1<<62, nil), 0},
+		{"largest 64-bit length", maskedFrame(0x80|wsOpText, 1<<63-1, nil), 0},
+		{"16-bit length over the limit", maskedFrame(0x80|wsOpText, 2000, nil), 0},
+		{"fragment over the limit", maskedFrame(wsOpContinuation, 100, nil), 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestWSConn(tt.frame, 1024)
+			_, _, _, err := c.readFrame(tt.buffer)
+			var closeErr *wsCloseError
+			if !errors.As(err, &closeErr) || closeErr.code != wsCloseMessageTooBig {
+				t.Errorf("error = %v, want message too big", err)
+			}
+		})
+	}
+}
+
+func TestWebSocketMaxMessageSizeDefault(t *testing.T) {
+	for _, size := range []int64{0, -1} {
+		m := newRegistryServer(t, WithWebSocketMaxMessageSize(size))
+		if m.wsMaxMessageSize != defaultWebSocketMaxMessageSize {
+			t.Errorf("WithWebSocketMaxMessageSize(%d) set %d, want the default", size, m.wsMaxMessageSize)
+		}
+	}
+}
+
+func TestCloseReasonTruncatedAtRuneBoundary(t *testing.T) {
+	// 62 two-byte characters make 124 bytes, one over the limit of 123
+	reason := strings.Repeat("é", 62)
+	c, conn := newTestWSConn(nil, 1024)
+	c.closeWithStatus(wsCloseProtocolError, reason)
+
+	frame := conn.written.Bytes()
+	payload := frame[2:]
+	if len(payload) > 125 {
+		t.Fatalf("close payload is %d bytes", len(payload))
+	}
+	if got := string(payload[2:]); got != strings.Repeat("é", 61) || !utf8.ValidString(got) {
+		t.Errorf("reason = %q", got)
+	}
+}
+
+func FuzzReadFrame(f *testing.F) {
+	f.Add(maskedFrame(0x80|wsOpText, 5, []byte("hello")))
+	f.Add(append(maskedFrame(wsOpText, 3, []byte("hel")), maskedFrame(0x80|wsOpContinuation, 2, []byte("lo"))...))
+	f.Add(append(maskedFrame(0x80|wsOpPing, 1, []byte("p")), maskedFrame(0x80|wsOpBinary, 1, []byte{0})...))
+	f.Add(maskedFrame(0x80|wsOpClose, 2, []byte{0x03, 0xe8}))
+	f.Add(maskedFrame(0x80|wsOpText, 200, bytes.Repeat([]byte("a"), 200)))
+	f.Add(maskedFrame(0x80|wsOpText, 1<<40, nil))
+	f.Add(maskedFrame(0x80|wsOpText, 2, []byte{0xff, 0xfe}))
+
+	const maxMessageSize = 256
+	f.Fuzz(func(t *testing.T, data []byte) {
+		c, _ := newTestWSConn(data, maxMessageSize)
+		for {
+			message, err := c.readMessage()
+			if err != nil {
+				return
+			}
+			if len(message) > maxMessageSize {
+				t.Fatalf("read a %d-byte message, limit %d", len(message), maxMessageSize)
+			}
+		}
+	})
+}
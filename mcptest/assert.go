@@ -0,0 +1,235 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcptest
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// ResultText returns the text content of a tool result, joining multiple text blocks with newlines
+func ResultText(result *mcp.CallToolResult) string {
+	if result == nil {
+		return ""
+	}
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// ResourceText returns the text of resource contents, joining multiple entries with newlines
+func ResourceText(contents []mcp.ResourceContents) string {
+	var texts []string
+	for _, content := range contents {
+		if text, ok := content.(mcp.TextResourceContents); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// PromptText returns the text of all prompt messages, joined with newlines
+func PromptText(result *mcp.GetPromptResult) string {
+	if result == nil {
+		return ""
+	}
+	var texts []string
+	for _, message := range result.Messages {
+		if text, ok := message.Content.(mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// AssertNotError fails the test if the tool result reports an error
+func AssertNotError(t testing.TB, result *mcp.CallToolResult) {
+	t.Helper()
+	if result == nil {
+		t.Fatalf("tool result is nil")
+	}
+	if result.IsError {
+		t.Errorf("tool returned an error: %s", ResultText(result))
+	}
+}
+
+// AssertIsError fails the test unless the tool result reports an error
+func AssertIsError(t testing.TB, result *mcp.CallToolResult) {
+	t.Helper()
+	if result == nil {
+		t.Fatalf("tool result is nil")
+	}
+	if !result.IsError {
+		t.Errorf("expected a tool error, got: %s", ResultText(result))
+	}
+}
+
+// AssertText fails the test unless the tool result text equals want
+func AssertText(t testing.TB, result *mcp.CallToolResult, want string) {
+	t.Helper()
+	if got := ResultText(result); got != want {
+		t.Errorf("tool result text = %q, want %q", got, want)
+	}
+}
+
+// AssertTextContains fails the test unless the tool result text contains substr
+func AssertTextContains(t testing.TB, result *mcp.CallToolResult, substr string) {
+	t.Helper()
+	if got := ResultText(result); !strings.Contains(got, substr) {
+		t.Errorf("tool result text %q does not contain %q", got, substr)
+	}
+}
+
+// AssertStructuredContent fails the test unless the structured content of the result equals
+// want after both are converted to JSON values, so structs and maps can be compared
+func AssertStructuredContent(t testing.TB, result *mcp.CallToolResult, want any) {
+	t.Helper()
+	if result == nil {
+		t.Fatalf("tool result is nil")
+	}
+	got := normalize(t, result.StructuredContent)
+	if expected := normalize(t, want); !reflect.DeepEqual(got, expected) {
+		t.Errorf("structured content = %s, want %s", toJSON(got), toJSON(expected))
+	}
+}
+
+// AssertResourceText fails the test unless the resource text equals want
+func AssertResourceText(t testing.TB, contents []mcp.ResourceContents, want string) {
+	t.Helper()
+	if got := ResourceText(contents); got != want {
+		t.Errorf("resource text = %q, want %q", got, want)
+	}
+}
+
+// AssertHints fails the test if any hint set in want differs from the tool's annotations.
+// Hints left nil in want are not checked.
+func AssertHints(t testing.TB, tool mcp.Tool, want mcptypes.ToolHints) {
+	t.Helper()
+	checks := []struct {
+		name      string
+		got, want *bool
+	}{
+		{"readOnlyHint", tool.Annotations.ReadOnlyHint, want.ReadOnlyHint},
+		{"destructiveHint", tool.Annotations.DestructiveHint, want.DestructiveHint},
+		{"idempotentHint", tool.Annotations.IdempotentHint, want.IdempotentHint},
+		{"openWorldHint", tool.Annotations.OpenWorldHint, want.OpenWorldHint},
+	}
+	for _, check := range checks {
+		if check.want == nil {
+			continue
+		}
+		if check.got == nil {
+			t.Errorf("tool '%s' %s is not set, want %t", tool.Name, check.name, *check.want)
+		} else if *check.got != *check.want {
+			t.Errorf("tool '%s' %s = %t, want %t", tool.Name, check.name, *check.got, *check.want)
+		}
+	}
+}
+
+// InputSchema returns the tool's input schema as a JSON value
+func InputSchema(t testing.TB, tool mcp.Tool) map[string]any {
+	t.Helper()
+	var schema any = tool.InputSchema
+	if tool.RawInputSchema != nil {
+		schema = tool.RawInputSchema
+	}
+	object, _ := normalize(t, schema).(map[string]any)
+	return object
+}
+
+// OutputSchema returns the tool's output schema as a JSON value, or nil if it has none
+func OutputSchema(t testing.TB, tool mcp.Tool) map[string]any {
+	t.Helper()
+	var schema any = tool.OutputSchema
+	if tool.RawOutputSchema != nil {
+		schema = tool.RawOutputSchema
+	}
+	object, _ := normalize(t, schema).(map[string]any)
+	if object["type"] == nil {
+		return nil
+	}
+	return object
+}
+
+// AssertParameter fails the test unless the tool's input schema declares the parameter with
+// the given JSON Schema type, returning the parameter's schema for further checks
+func AssertParameter(t testing.TB, tool mcp.Tool, name, paramType string) map[string]any {
+	t.Helper()
+	properties, _ := InputSchema(t, tool)["properties"].(map[string]any)
+	property, ok := properties[name].(map[string]any)
+	if !ok {
+		t.Errorf("tool '%s' has no parameter '%s'", tool.Name, name)
+		return nil
+	}
+	if property["type"] != paramType {
+		t.Errorf("tool '%s' parameter '%s' has type %v, want %s", tool.Name, name, property["type"], paramType)
+	}
+	return property
+}
+
+// AssertRequired fails the test unless the tool's required parameters are exactly names, in any order
+func AssertRequired(t testing.TB, tool mcp.Tool, names ...string) {
+	t.Helper()
+	var got []string
+	if required, ok := InputSchema(t, tool)["required"].([]any); ok {
+		for _, name := range required {
+			got = append(got, name.(string))
+		}
+	}
+	want := slices.Clone(names)
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("tool '%s' required parameters = %v, want %v", tool.Name, got, want)
+	}
+}
+
+// AssertSchema fails the test unless the tool's input schema equals want after both are
+// converted to JSON values
+func AssertSchema(t testing.TB, tool mcp.Tool, want map[string]any) {
+	t.Helper()
+	got := InputSchema(t, tool)
+	if expected := normalize(t, want); !reflect.DeepEqual(any(got), expected) {
+		t.Errorf("tool '%s' input schema = %s, want %s", tool.Name, toJSON(got), toJSON(expected))
+	}
+}
+
+// normalize converts a value to its generic JSON representation
+func normalize(t testing.TB, value any) any {
+	t.Helper()
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("unable to encode %T as JSON: %v", value, err)
+	}
+	var normalized any
+	if err = json.Unmarshal(data, &normalized); err != nil {
+		t.Fatalf("unable to decode JSON: %v", err)
+	}
+	return normalized
+}
+
+// toJSON formats a value for failure messages
+func toJSON(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "<invalid JSON>"
+	}
+	return string(data)
+}
@@ -0,0 +1,203 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcptest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Issuer is a local stand-in for an authorization server. It publishes a JSON Web Key Set
+// over HTTP and signs tokens with RS256, ES256, EdDSA or HS256, so JWT validation can be
+// tested without a real identity provider.
+//
+//	issuer := mcptest.NewIssuer(t)
+//	v, _ := jwt.New(jwt.WithJWKSURL(issuer.JWKSURL()), jwt.WithIssuer(issuer.URL()))
+//	token := issuer.Token("RS256", map[string]any{"sub": "alice", "aud": "https://mcp.example.com"})
+type Issuer struct {
+	t       testing.TB
+	server  *httptest.Server
+	secret  []byte
+	fetches atomic.Int32
+
+	mu         sync.Mutex
+	generation int
+	rsaKey     *rsa.PrivateKey
+	ecKey      *ecdsa.PrivateKey
+	edKey      ed25519.PrivateKey
+}
+
+// NewIssuer starts an issuer with fresh keys. The server is closed when the test ends.
+func NewIssuer(t testing.TB) *Issuer {
+	t.Helper()
+
+	i := &Issuer{t: t, secret: make([]byte, 32)}
+	if _, err := rand.Read(i.secret); err != nil {
+		t.Fatalf("mcptest: unable to generate secret: %v", err)
+	}
+	i.Rotate()
+
+	i.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/jwks.json" {
+			http.NotFound(w, r)
+			return
+		}
+		i.fetches.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(i.jwks())
+	}))
+	t.Cleanup(i.server.Close)
+	return i
+}
+
+// URL returns the issuer identifier, which is the base URL of the server
+func (i *Issuer) URL() string {
+	return i.server.URL
+}
+
+// JWKSURL returns the URL of the JSON Web Key Set
+func (i *Issuer) JWKSURL() string {
+	return i.server.URL + "/jwks.json"
+}
+
+// Secret returns the shared secret used for HS256 tokens
+func (i *Issuer) Secret() []byte {
+	return i.secret
+}
+
+// Fetches returns the number of times the key set has been fetched
+func (i *Issuer) Fetches() int {
+	return int(i.fetches.Load())
+}
+
+// Rotate replaces the signing keys with new keys with new key IDs. The old keys are no
+// longer published, so tokens signed with them stop validating once the key set is fetched.
+func (i *Issuer) Rotate() {
+	i.t.Helper()
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var err error
+	if i.rsaKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		i.t.Fatalf("mcptest: unable to generate RSA key: %v", err)
+	}
+	if i.ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+		i.t.Fatalf("mcptest: unable to generate EC key: %v", err)
+	}
+	if _, i.edKey, err = ed25519.GenerateKey(rand.Reader); err != nil {
+		i.t.Fatalf("mcptest: unable to generate Ed25519 key: %v", err)
+	}
+	i.generation++
+}
+
+// Token returns a token signed with the algorithm (RS256, ES256, EdDSA or HS256). The iss,
+// iat and exp claims default to the issuer URL, now and one hour from now; a claim set to
+// nil is left out.
+func (i *Issuer) Token(algorithm string, claims map[string]any) string {
+	i.t.Helper()
+
+	now := time.Now()
+	payload := map[string]any{
+		"iss": i.URL(),
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	for name, value := range claims {
+		if value == nil {
+			delete(payload, name)
+		} else {
+			payload[name] = value
+		}
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	header := map[string]any{"alg": algorithm, "typ": "JWT"}
+	if algorithm != "HS256" {
+		header["kid"] = i.keyID(algorithm)
+	}
+	input := encodeSegment(i.t, header) + "." + encodeSegment(i.t, payload)
+	digest := sha256.Sum256([]byte(input))
+
+	var signature []byte
+	var err error
+	switch algorithm {
+	case "RS256":
+		signature, err = rsa.SignPKCS1v15(rand.Reader, i.rsaKey, crypto.SHA256, digest[:])
+	case "ES256":
+		// JWS uses the fixed-size concatenation of r and s rather than ASN.1
+		r, s, signErr := ecdsa.Sign(rand.Reader, i.ecKey, digest[:])
+		if err = signErr; err == nil {
+			signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+		}
+	case "EdDSA":
+		signature = ed25519.Sign(i.edKey, []byte(input))
+	case "HS256":
+		mac := hmac.New(sha256.New, i.secret)
+		mac.Write([]byte(input))
+		signature = mac.Sum(nil)
+	default:
+		i.t.Fatalf("mcptest: unsupported algorithm %s", algorithm)
+	}
+	if err != nil {
+		i.t.Fatalf("mcptest: unable to sign token: %v", err)
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// keyID returns the ID of the current key for an algorithm
+func (i *Issuer) keyID(algorithm string) string {
+	return fmt.Sprintf("%s-%d", algorithm, i.generation)
+}
+
+// jwks returns the key set document with the current public keys
+func (i *Issuer) jwks() map[string]any {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	b64 := base64.RawURLEncoding.EncodeToString
+	return map[string]any{"keys": []map[string]any{
+		{
+			"kty": "RSA", "use": "sig", "alg": "RS256", "kid": i.keyID("RS256"),
+			"n": b64(i.rsaKey.N.Bytes()),
+			"e": b64([]byte{1, 0, 1}),
+		},
+		{
+			"kty": "EC", "use": "sig", "alg": "ES256", "kid": i.keyID("ES256"), "crv": "P-256",
+			"x": b64(i.ecKey.X.FillBytes(make([]byte, 32))),
+			"y": b64(i.ecKey.Y.FillBytes(make([]byte, 32))),
+		},
+		{
+			"kty": "OKP", "use": "sig", "alg": "EdDSA", "kid": i.keyID("EdDSA"), "crv": "Ed25519",
+			"x": b64(i.edKey.Public().(ed25519.PublicKey)),
+		},
+	}}
+}
+
+// encodeSegment encodes a token header or payload
+func encodeSegment(t testing.TB, value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("mcptest: unable to encode token: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
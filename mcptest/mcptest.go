@@ -0,0 +1,288 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+// Package mcptest runs an MCPServer in memory and connects an MCP client to it, so that
+// providers can be tested end to end through the real protocol without opening ports.
+//
+//	func TestWeather(t *testing.T) {
+//		c := mcptest.NewClient(t, mcpserver.WithToolProviders([]mcptypes.ToolProvider{weather.New()}))
+//		result := c.CallTool("get_forecast", map[string]any{"city": "Ottawa"})
+//		mcptest.AssertTextContains(t, result, "Ottawa")
+//	}
+package mcptest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcpserver"
+)
+
+// DefaultTimeout bounds each request made by the client helpers
+const DefaultTimeout = 10 * time.Second
+
+// Client is an initialized MCP client connected to an in-memory MCPServer. Helper methods
+// fail the test on protocol errors; use MCPClient() for direct access to the client.
+type Client struct {
+	t       testing.TB
+	server  *mcpserver.MCPServer
+	client  *client.Client
+	timeout time.Duration
+
+	mu            sync.Mutex
+	notifications []mcp.JSONRPCNotification
+	notified      chan struct{}
+}
+
+// NewClient starts an MCPServer configured with the given options, connects a client over
+// an in-memory pipe and completes the initialize handshake. The server and client are shut
+// down when the test ends. Options should configure providers, not network transports.
+func NewClient(t testing.TB, options ...mcpserver.Option) *Client {
+	t.Helper()
+	return newClient(t, "", options)
+}
+
+// NewAuthenticatedClient is like NewClient, but the client authenticates with a bearer token
+// checked by the validator set with mcpserver.WithBearerTokenAuth, as a stdio client does.
+// The test fails if the token is rejected.
+func NewAuthenticatedClient(t testing.TB, token string, options ...mcpserver.Option) *Client {
+	t.Helper()
+	return newClient(t, token, options)
+}
+
+// newClient starts the server and connects a client, authenticating the session with token
+// if a bearer token validator is configured
+func newClient(t testing.TB, token string, options []mcpserver.Option) *Client {
+	t.Helper()
+
+	pipe, clientReader, clientWriter := newPipeTransport()
+	srv, err := mcpserver.New(append(options, mcpserver.WithTransport(pipe))...)
+	if err != nil {
+		t.Fatalf("mcptest: unable to create server: %v", err)
+	}
+	pipe.authenticate = func(ctx context.Context) (context.Context, error) {
+		return srv.AuthenticateSession(ctx, nil, token)
+	}
+	if err = srv.Start(); err != nil {
+		t.Fatalf("mcptest: unable to start server: %v", err)
+	}
+
+	c := &Client{
+		t:        t,
+		server:   srv,
+		client:   client.NewClient(transport.NewIO(clientReader, clientWriter, nil)),
+		timeout:  DefaultTimeout,
+		notified: make(chan struct{}, 1),
+	}
+	t.Cleanup(func() {
+		_ = c.client.Close()
+		if err := srv.Stop(); err != nil {
+			t.Errorf("mcptest: server did not stop cleanly: %v", err)
+		}
+	})
+
+	c.client.OnNotification(c.recordNotification)
+
+	ctx, cancel := c.context()
+	defer cancel()
+	if err = c.client.Start(ctx); err != nil {
+		t.Fatalf("mcptest: unable to start client: %v", err)
+	}
+
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "mcptest", Version: "1.0.0"}
+	if _, err = c.client.Initialize(ctx, initRequest); err != nil {
+		t.Fatalf("mcptest: initialize failed: %v", err)
+	}
+	return c
+}
+
+// WithTimeout sets the timeout for each subsequent request and returns the client
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	c.timeout = timeout
+	return c
+}
+
+// Server returns the MCPServer under test, for example to register tools at runtime
+func (c *Client) Server() *mcpserver.MCPServer {
+	return c.server
+}
+
+// MCPClient returns the underlying mcp-go client
+func (c *Client) MCPClient() *client.Client {
+	return c.client
+}
+
+// ListTools returns all tools advertised by the server
+func (c *Client) ListTools() []mcp.Tool {
+	c.t.Helper()
+	ctx, cancel := c.context()
+	defer cancel()
+
+	result, err := c.client.ListTools(ctx, mcp.ListToolsRequest{})
+	if err != nil {
+		c.t.Fatalf("mcptest: tools/list failed: %v", err)
+	}
+	return result.Tools
+}
+
+// Tool returns the named tool, failing the test if the server does not advertise it
+func (c *Client) Tool(name string) mcp.Tool {
+	c.t.Helper()
+	for _, tool := range c.ListTools() {
+		if tool.Name == name {
+			return tool
+		}
+	}
+	c.t.Fatalf("mcptest: tool '%s' not found", name)
+	return mcp.Tool{}
+}
+
+// CallTool calls a tool and returns its result, failing the test on a protocol error.
+// Tool errors reported in the result (IsError) do not fail the test.
+func (c *Client) CallTool(name string, arguments map[string]any) *mcp.CallToolResult {
+	c.t.Helper()
+	result, err := c.CallToolErr(name, arguments)
+	if err != nil {
+		c.t.Fatalf("mcptest: tools/call '%s' failed: %v", name, err)
+	}
+	return result
+}
+
+// CallToolErr calls a tool and returns its result and any protocol error, for tests that
+// expect the call to fail
+func (c *Client) CallToolErr(name string, arguments map[string]any) (*mcp.CallToolResult, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = arguments
+	return c.client.CallTool(ctx, request)
+}
+
+// ListResources returns all resources advertised by the server
+func (c *Client) ListResources() []mcp.Resource {
+	c.t.Helper()
+	ctx, cancel := c.context()
+	defer cancel()
+
+	result, err := c.client.ListResources(ctx, mcp.ListResourcesRequest{})
+	if err != nil {
+		c.t.Fatalf("mcptest: resources/list failed: %v", err)
+	}
+	return result.Resources
+}
+
+// ListResourceTemplates returns all resource templates advertised by the server
+func (c *Client) ListResourceTemplates() []mcp.ResourceTemplate {
+	c.t.Helper()
+	ctx, cancel := c.context()
+	defer cancel()
+
+	result, err := c.client.ListResourceTemplates(ctx, mcp.ListResourceTemplatesRequest{})
+	if err != nil {
+		c.t.Fatalf("mcptest: resources/templates/list failed: %v", err)
+	}
+	return result.ResourceTemplates
+}
+
+// ReadResource reads a resource by URI, failing the test on error
+func (c *Client) ReadResource(uri string) []mcp.ResourceContents {
+	c.t.Helper()
+	ctx, cancel := c.context()
+	defer cancel()
+
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = uri
+	result, err := c.client.ReadResource(ctx, request)
+	if err != nil {
+		c.t.Fatalf("mcptest: resources/read '%s' failed: %v", uri, err)
+	}
+	return result.Contents
+}
+
+// ListPrompts returns all prompts advertised by the server
+func (c *Client) ListPrompts() []mcp.Prompt {
+	c.t.Helper()
+	ctx, cancel := c.context()
+	defer cancel()
+
+	result, err := c.client.ListPrompts(ctx, mcp.ListPromptsRequest{})
+	if err != nil {
+		c.t.Fatalf("mcptest: prompts/list failed: %v", err)
+	}
+	return result.Prompts
+}
+
+// GetPrompt renders a prompt with the given arguments, failing the test on error
+func (c *Client) GetPrompt(name string, arguments map[string]string) *mcp.GetPromptResult {
+	c.t.Helper()
+	ctx, cancel := c.context()
+	defer cancel()
+
+	request := mcp.GetPromptRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = arguments
+	result, err := c.client.GetPrompt(ctx, request)
+	if err != nil {
+		c.t.Fatalf("mcptest: prompts/get '%s' failed: %v", name, err)
+	}
+	return result
+}
+
+// Notifications returns the notifications received from the server so far
+func (c *Client) Notifications() []mcp.JSONRPCNotification {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]mcp.JSONRPCNotification(nil), c.notifications...)
+}
+
+// WaitForNotification waits for a notification with the given method, such as
+// mcp.MethodNotificationToolsListChanged, failing the test if none arrives within the timeout.
+// Notifications received earlier are matched too.
+func (c *Client) WaitForNotification(method string) mcp.JSONRPCNotification {
+	c.t.Helper()
+	deadline := time.NewTimer(c.timeout)
+	defer deadline.Stop()
+
+	for {
+		for _, notification := range c.Notifications() {
+			if notification.Method == method {
+				return notification
+			}
+		}
+		select {
+		case <-c.notified:
+		case <-deadline.C:
+			c.t.Fatalf("mcptest: no '%s' notification received within %s", method, c.timeout)
+			return mcp.JSONRPCNotification{}
+		}
+	}
+}
+
+// recordNotification stores a notification and wakes WaitForNotification
+func (c *Client) recordNotification(notification mcp.JSONRPCNotification) {
+	c.mu.Lock()
+	c.notifications = append(c.notifications, notification)
+	c.mu.Unlock()
+
+	select {
+	case c.notified <- struct{}{}:
+	default:
+	}
+}
+
+// context returns a context bounded by the request timeout
+func (c *Client) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.timeout)
+}
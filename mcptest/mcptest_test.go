@@ -0,0 +1,194 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcptest_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/PivotLLM/MCPLaunchPad/example1"
+	"github.com/PivotLLM/MCPLaunchPad/example2"
+	"github.com/PivotLLM/MCPLaunchPad/mcpserver"
+	"github.com/PivotLLM/MCPLaunchPad/mcptest"
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// widgetAPI stands in for the widget API of example1. It checks the API key and answers
+// with the method, request URI and body it received.
+func widgetAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "test-key" {
+			http.Error(w, "bad key", http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_, _ = fmt.Fprintf(w, "%s %s %s", r.Method, r.URL.RequestURI(), body)
+	}))
+	t.Cleanup(api.Close)
+	return api
+}
+
+// newExampleClient connects a client to a server offering the example providers
+func newExampleClient(t *testing.T, baseURL string) *mcptest.Client {
+	t.Helper()
+	p1 := example1.New(
+		example1.WithBaseURL(baseURL),
+		example1.WithAuthHeader("X-API-Key"),
+		example1.WithAuthKey("test-key"),
+	)
+	p2 := example2.New()
+	return mcptest.NewClient(t,
+		mcpserver.WithToolProviders([]mcptypes.ToolProvider{p1, p2}),
+		mcpserver.WithResourceProviders([]mcptypes.ResourceProvider{p1}),
+		mcpserver.WithPromptProviders([]mcptypes.PromptProvider{p1}),
+	)
+}
+
+func TestExampleTools(t *testing.T) {
+	api := widgetAPI(t)
+
+	tests := []struct {
+		name      string
+		tool      string
+		arguments map[string]any
+		fails     bool           // The call fails with a protocol or tool error
+		text      string         // Expected text, if not empty
+		pattern   *regexp.Regexp // Pattern the text must match, if not nil
+	}{
+		{name: "24-hour time", tool: "get_time", arguments: map[string]any{"time_format": "24"}, pattern: regexp.MustCompile(`^\d{2}:\d{2}:\d{2}$`)},
+		{name: "12-hour time", tool: "get_time", arguments: map[string]any{"time_format": "12"}, pattern: regexp.MustCompile(`^\d{2}:\d{2}:\d{2} (AM|PM)$`)},
+		{name: "default time format", tool: "get_time", pattern: regexp.MustCompile(`(AM|PM)$`)},
+		{name: "invalid time format", tool: "get_time", arguments: map[string]any{"time_format": "13"}, fails: true},
+		{name: "list widgets", tool: "list_widgets", arguments: map[string]any{"offset": 10, "limit": 5}, text: "GET /widget?limit=5&offset=10 "},
+		{name: "get widget", tool: "get_widget", arguments: map[string]any{"id": "42"}, text: "GET /widget/42 "},
+		{name: "delete widget", tool: "delete_widget", arguments: map[string]any{"id": "42"}, text: "DELETE /widget/42 "},
+		{
+			name:      "create widget",
+			tool:      "create_widget",
+			arguments: map[string]any{"name": "Wheel", "description": "Round", "radius": 2.5},
+			text:      `POST /widget {"description":"Round","name":"Wheel","radius":2.5}`,
+		},
+		{name: "missing required argument", tool: "create_widget", arguments: map[string]any{"name": "Wheel"}, fails: true},
+		{name: "wrong argument type", tool: "list_widgets", arguments: map[string]any{"limit": "five"}, fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newExampleClient(t, api.URL)
+			if tt.fails {
+				if result, err := c.CallToolErr(tt.tool, tt.arguments); err == nil && !result.IsError {
+					t.Fatalf("call succeeded: %s", mcptest.ResultText(result))
+				}
+				return
+			}
+			result := c.CallTool(tt.tool, tt.arguments)
+			mcptest.AssertNotError(t, result)
+			if tt.text != "" {
+				mcptest.AssertText(t, result, tt.text)
+			}
+			if tt.pattern != nil && !tt.pattern.MatchString(mcptest.ResultText(result)) {
+				t.Errorf("text %q does not match %s", mcptest.ResultText(result), tt.pattern)
+			}
+		})
+	}
+}
+
+func TestExampleToolSchemas(t *testing.T) {
+	tests := []struct {
+		tool     string
+		params   map[string]string // Parameter name to JSON Schema type
+		required []string
+		hints    mcptypes.ToolHints
+	}{
+		{tool: "get_time", params: map[string]string{"time_format": "string"}},
+		{
+			tool:   "list_widgets",
+			params: map[string]string{"offset": "integer", "limit": "integer"},
+			hints:  *mcptypes.NewHints().ReadOnly(true),
+		},
+		{
+			tool:     "create_widget",
+			params:   map[string]string{"name": "string", "description": "string", "radius": "number"},
+			required: []string{"name", "description"},
+			hints:    *mcptypes.NewHints().ReadOnly(false).Destructive(false),
+		},
+		{
+			tool:     "delete_widget",
+			params:   map[string]string{"id": "string"},
+			required: []string{"id"}, // Path parameters are always required
+			hints:    *mcptypes.NewHints().Destructive(true),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			tool := newExampleClient(t, "http://unused.invalid").Tool(tt.tool)
+			for name, paramType := range tt.params {
+				mcptest.AssertParameter(t, tool, name, paramType)
+			}
+			mcptest.AssertRequired(t, tool, tt.required...)
+			mcptest.AssertHints(t, tool, tt.hints)
+		})
+	}
+}
+
+func TestExampleResourcesAndPrompts(t *testing.T) {
+	tests := []struct {
+		name string
+		uri  string
+		want string // Text the resource must contain
+	}{
+		{"readme", "file:///home/readme.txt", "This is a simple readme file."},
+		{"template", "abc:///info/q", "information about 'q'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contents := newExampleClient(t, "http://unused.invalid").ReadResource(tt.uri)
+			if text := mcptest.ResourceText(contents); !strings.Contains(text, tt.want) {
+				t.Errorf("resource %s = %q, want it to contain %q", tt.uri, text, tt.want)
+			}
+		})
+	}
+
+	c := newExampleClient(t, "http://unused.invalid")
+	if templates := c.ListResourceTemplates(); len(templates) != 1 || templates[0].URITemplate.Raw() != "abc:///info/{letter_or_number}" {
+		t.Errorf("unexpected resource templates %v", templates)
+	}
+
+	result := c.GetPrompt("greeting", map[string]string{"name": "Ada"})
+	if text := mcptest.PromptText(result); text != "Hello, Ada! How can I help you today?" {
+		t.Errorf("prompt text = %q", text)
+	}
+}
+
+func TestNotifications(t *testing.T) {
+	c := newExampleClient(t, "http://unused.invalid")
+
+	err := c.Server().RegisterTool(mcptypes.ToolDefinition{
+		Name:    "added",
+		Handler: func(map[string]any) (string, error) { return "added", nil },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.WaitForNotification(mcp.MethodNotificationToolsListChanged)
+	mcptest.AssertText(t, c.CallTool("added", nil), "added")
+
+	if !c.Server().UnregisterTool("added") {
+		t.Fatal("tool was not registered")
+	}
+	if _, err = c.CallToolErr("added", nil); err == nil {
+		t.Error("removed tool can still be called")
+	}
+}
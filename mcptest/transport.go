@@ -0,0 +1,143 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcptest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxMessageSize is the largest JSON-RPC message the pipe transport accepts
+const maxMessageSize = 16 << 20
+
+// pipeTransport is an mcpserver.Transport that serves one client over in-memory pipes,
+// exchanging newline-delimited JSON-RPC messages like the stdio transport
+type pipeTransport struct {
+	fromClient *io.PipeReader // Server side of client-to-server pipe
+	toClient   *io.PipeWriter // Server side of server-to-client pipe
+
+	// authenticate prepares the session context, as mcpserver.AuthenticateSession does
+	authenticate func(ctx context.Context) (context.Context, error)
+
+	writeMu sync.Mutex
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// newPipeTransport returns the transport and the client ends of its pipes
+func newPipeTransport() (*pipeTransport, io.Reader, io.WriteCloser) {
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+	t := &pipeTransport{fromClient: serverReader, toClient: serverWriter}
+	return t, clientReader, clientWriter
+}
+
+// Name implements mcpserver.Transport
+func (t *pipeTransport) Name() string {
+	return "mcptest pipe"
+}
+
+// Start implements mcpserver.Transport
+func (t *pipeTransport) Start(ctx context.Context, srv *server.MCPServer) error {
+	ctx, t.cancel = context.WithCancel(ctx)
+	t.done = make(chan struct{})
+
+	session := &pipeSession{notifications: make(chan mcp.JSONRPCNotification, 100)}
+	if err := srv.RegisterSession(ctx, session); err != nil {
+		t.cancel()
+		return err
+	}
+	ctx = srv.WithContext(ctx, session)
+	if t.authenticate != nil {
+		authCtx, err := t.authenticate(ctx)
+		if err != nil {
+			srv.UnregisterSession(ctx, session.SessionID())
+			t.cancel()
+			return err
+		}
+		ctx = authCtx
+	}
+
+	// Forward notifications to the client
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case notification := <-session.notifications:
+				t.send(notification)
+			}
+		}
+	}()
+
+	// Handle requests concurrently, as the network transports do
+	go func() {
+		defer close(t.done)
+		defer srv.UnregisterSession(ctx, session.SessionID())
+
+		scanner := bufio.NewScanner(t.fromClient)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+		for scanner.Scan() {
+			message := append([]byte(nil), scanner.Bytes()...)
+			go func() {
+				if response := srv.HandleMessage(ctx, message); response != nil {
+					t.send(response)
+				}
+			}()
+		}
+	}()
+	return nil
+}
+
+// send writes a JSON-RPC message followed by a newline
+func (t *pipeTransport) send(message any) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	_, _ = t.toClient.Write(append(data, '\n'))
+}
+
+// Shutdown implements mcpserver.Transport
+func (t *pipeTransport) Shutdown(ctx context.Context) error {
+	if t.cancel == nil {
+		return nil
+	}
+	t.cancel()
+	_ = t.fromClient.Close()
+	_ = t.toClient.Close()
+	select {
+	case <-t.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// pipeSession is the client session of the pipe transport
+type pipeSession struct {
+	notifications chan mcp.JSONRPCNotification
+	initialized   atomic.Bool
+}
+
+func (s *pipeSession) SessionID() string { return "mcptest" }
+
+func (s *pipeSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func (s *pipeSession) Initialize() { s.initialized.Store(true) }
+
+func (s *pipeSession) Initialized() bool { return s.initialized.Load() }
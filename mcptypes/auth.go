@@ -5,7 +5,10 @@
 
 package mcptypes
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // This file contains authentication-related interfaces for future implementation.
 // These are stubs/placeholders to establish the API contract.
@@ -14,6 +17,22 @@ import "context"
 // Implement this interface and pass it via WithBearerTokenAuth() option.
 type BearerTokenValidator func(token string) (contextData map[string]any, err error)
 
+// Authentication methods recorded in AuthInfo.Method
+const (
+	AuthMethodBearer = "bearer" // Bearer token checked by a BearerTokenValidator
+	AuthMethodTLS    = "tls"    // Verified TLS client certificate (mutual TLS)
+)
+
+// AuthInfo describes the authenticated caller of a request. mcpserver.AuthFromContext returns
+// it from the context passed to handlers.
+type AuthInfo struct {
+	Subject   string         // The "sub" value, or the TLS client certificate subject
+	Scopes    []string       // Scopes granted by the "scope" or "scp" value
+	Claims    map[string]any // Context data returned by the validator, plus client certificate data
+	ExpiresAt time.Time      // Expiry of the token ("exp"), zero if unknown
+	Method    string         // How the caller authenticated, e.g. AuthMethodBearer
+}
+
 // OAuth2Provider defines the interface for OAuth2 authentication providers.
 // Implement this interface to add OAuth2 support to your MCP server.
 type OAuth2Provider interface {
@@ -21,3 +21,20 @@ type Logger interface {
 	Fatalf(string, ...any)
 	Close()
 }
+
+// NoopLogger is a Logger that discards all messages, for packages used without a logger
+type NoopLogger struct{}
+
+func (NoopLogger) Debug(string)            {}
+func (NoopLogger) Info(string)             {}
+func (NoopLogger) Notice(string)           {}
+func (NoopLogger) Warning(string)          {}
+func (NoopLogger) Error(string)            {}
+func (NoopLogger) Fatal(string)            {}
+func (NoopLogger) Debugf(string, ...any)   {}
+func (NoopLogger) Infof(string, ...any)    {}
+func (NoopLogger) Noticef(string, ...any)  {}
+func (NoopLogger) Warningf(string, ...any) {}
+func (NoopLogger) Errorf(string, ...any)   {}
+func (NoopLogger) Fatalf(string, ...any)   {}
+func (NoopLogger) Close()                  {}
@@ -5,6 +5,11 @@
 
 package mcptypes
 
+import (
+	"context"
+	"encoding/json"
+)
+
 //
 // Tools
 //
@@ -16,16 +21,74 @@ type ToolDefinition struct {
 	Parameters  []*Parameter
 	Handler     ToolHandler
 	Hints       *ToolHints // Optional hint overrides
+
+	// HandlerContext is an optional context-aware handler. If set, it is used instead of Handler.
+	HandlerContext ToolHandlerContext
+
+	// HandlerResult is an optional handler returning rich results. If set, it is used instead of
+	// HandlerContext and Handler.
+	HandlerResult ToolHandlerResult
+
+	// OutputSchema optionally describes the properties of the tool's structured content
+	OutputSchema []*Parameter
+
+	// RawInputSchema and RawOutputSchema optionally replace the schemas built from Parameters
+	// and OutputSchema with JSON Schemas published verbatim, such as those of an upstream server
+	RawInputSchema  json.RawMessage
+	RawOutputSchema json.RawMessage
+
+	// SkipValidation disables server-side defaults, coercion and validation for this tool, so
+	// the handler receives the arguments exactly as sent
+	SkipValidation bool
+
+	// Scopes and Roles restrict the tool to callers whose token grants every scope and at
+	// least one of the roles. The tool is hidden from other callers.
+	Scopes []string
+	Roles  []string
 }
 
 // ToolHandler defines the function signature for tool handlers
 type ToolHandler func(options map[string]any) (string, error)
 
+// ToolHandlerContext defines the function signature for context-aware tool handlers.
+// The context carries the caller's auth info and is cancelled when the client cancels the
+// call with a notifications/cancelled notification.
+type ToolHandlerContext func(ctx context.Context, req ToolRequest, options map[string]any) (string, error)
+
+// ToolHandlerResult defines the function signature for tool handlers returning rich results
+type ToolHandlerResult func(ctx context.Context, req ToolRequest, options map[string]any) (*ToolResult, error)
+
+// ToolRequest describes the tool call being handled by a context-aware handler
+type ToolRequest struct {
+	ToolName  string         // Name of the tool being called
+	SessionID string         // MCP session ID (empty if the transport has no session)
+	RequestID string         // JSON-RPC request ID of the call, e.g. "7"
+	Auth      map[string]any // Context data returned by the BearerTokenValidator (nil if unauthenticated)
+	AuthInfo  *AuthInfo      // The authenticated caller (nil if unauthenticated)
+}
+
 // ToolProvider defines an interface for providing tools
 type ToolProvider interface {
 	RegisterTools() []ToolDefinition
 }
 
+// DynamicToolProvider is an optional interface for tool providers whose tools change at runtime.
+// Watch is called once the server starts. The provider sends on the returned channel whenever
+// RegisterTools would return a different set, and closes it when ctx is cancelled. If a provider
+// implements several Dynamic*Provider interfaces, Watch is called once for each and every call
+// must return its own channel.
+type DynamicToolProvider interface {
+	ToolProvider
+	Watch(ctx context.Context) <-chan struct{}
+}
+
+// StoppableProvider is an optional interface for providers that run in the background, such as
+// a gateway. The server calls Stop when it stops, after its transports have shut down, and
+// waits for it to return.
+type StoppableProvider interface {
+	Stop(ctx context.Context) error
+}
+
 //
 // Resources
 //
@@ -37,6 +100,11 @@ type ResourceDefinition struct {
 	MIMEType    string
 	URI         string
 	Handler     ResourceHandler
+	Scopes      []string // Scopes the caller must all have (see ToolDefinition)
+	Roles       []string // Roles of which the caller must have at least one
+
+	// HandlerContext is an optional context-aware handler. If set, it is used instead of Handler.
+	HandlerContext ResourceHandlerContext
 }
 
 // ResourceTemplateDefinition represents the structure of a resource template
@@ -46,6 +114,11 @@ type ResourceTemplateDefinition struct {
 	MIMEType    string
 	URITemplate string
 	Handler     ResourceHandler
+	Scopes      []string // Scopes the caller must all have (see ToolDefinition)
+	Roles       []string // Roles of which the caller must have at least one
+
+	// HandlerContext is an optional context-aware handler. If set, it is used instead of Handler.
+	HandlerContext ResourceHandlerContext
 }
 
 // ResourceResponse represents the structure of a resource response
@@ -58,12 +131,23 @@ type ResourceResponse struct {
 // ResourceHandler defines the function signature for resource handlers
 type ResourceHandler func(uri string, options map[string]any) (ResourceResponse, error)
 
+// ResourceHandlerContext defines the function signature for context-aware resource handlers.
+// The context carries the caller's auth info.
+type ResourceHandlerContext func(ctx context.Context, uri string, options map[string]any) (ResourceResponse, error)
+
 // ResourceProvider defines an interface for providing resources
 type ResourceProvider interface {
 	RegisterResources() []ResourceDefinition
 	RegisterResourceTemplates() []ResourceTemplateDefinition
 }
 
+// DynamicResourceProvider is an optional interface for resource providers whose resources or
+// resource templates change at runtime. See DynamicToolProvider for the Watch contract.
+type DynamicResourceProvider interface {
+	ResourceProvider
+	Watch(ctx context.Context) <-chan struct{}
+}
+
 //
 // Prompts
 //
@@ -74,6 +158,11 @@ type PromptDefinition struct {
 	Description string
 	Parameters  []*Parameter
 	Handler     PromptHandler
+	Scopes      []string // Scopes the caller must all have (see ToolDefinition)
+	Roles       []string // Roles of which the caller must have at least one
+
+	// HandlerContext is an optional context-aware handler. If set, it is used instead of Handler.
+	HandlerContext PromptHandlerContext
 }
 
 // Messages represents a collection of messages
@@ -88,7 +177,18 @@ type Message struct {
 // PromptHandler defines the function signature for prompt handlers
 type PromptHandler func(options map[string]any) (string, Messages, error)
 
+// PromptHandlerContext defines the function signature for context-aware prompt handlers.
+// The context carries the caller's auth info.
+type PromptHandlerContext func(ctx context.Context, options map[string]any) (string, Messages, error)
+
 // PromptProvider defines an interface for providing prompts
 type PromptProvider interface {
 	RegisterPrompts() []PromptDefinition
 }
+
+// DynamicPromptProvider is an optional interface for prompt providers whose prompts change at
+// runtime. See DynamicToolProvider for the Watch contract.
+type DynamicPromptProvider interface {
+	PromptProvider
+	Watch(ctx context.Context) <-chan struct{}
+}
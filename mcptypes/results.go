@@ -0,0 +1,89 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcptypes
+
+// ContentType identifies the kind of a content block in a tool result
+type ContentType string
+
+const (
+	ContentTypeText         ContentType = "text"          // Plain text
+	ContentTypeImage        ContentType = "image"         // Binary image data
+	ContentTypeAudio        ContentType = "audio"         // Binary audio data
+	ContentTypeResource     ContentType = "resource"      // Embedded resource (text or blob)
+	ContentTypeResourceLink ContentType = "resource_link" // Link to a resource the client can read
+)
+
+// Content represents a single content block in a tool result.
+// Use the New*Content constructors rather than populating it directly.
+type Content struct {
+	Type        ContentType
+	Text        string // Text for text content and embedded text resources
+	Data        []byte // Raw bytes for image, audio and embedded blob resources (base64-encoded on the wire)
+	MIMEType    string
+	URI         string // Resource URI for embedded resources and resource links
+	Name        string // Resource name for resource links
+	Description string // Resource description for resource links
+}
+
+// ToolResult represents a rich tool result with multiple content blocks and optional structured content
+type ToolResult struct {
+	Content []Content
+
+	// StructuredContent is a machine-readable result. It must marshal to a JSON object and, if the
+	// tool declares an OutputSchema, conform to it. A JSON text block is added automatically when
+	// Content is empty.
+	StructuredContent any
+
+	// IsError indicates that the tool ran but the call failed
+	IsError bool
+}
+
+// NewToolResult creates a ToolResult with the given content blocks
+func NewToolResult(content ...Content) *ToolResult {
+	return &ToolResult{Content: content}
+}
+
+// WithStructuredContent sets the structured content and returns the result for chaining
+func (r *ToolResult) WithStructuredContent(value any) *ToolResult {
+	r.StructuredContent = value
+	return r
+}
+
+// WithContent appends content blocks and returns the result for chaining
+func (r *ToolResult) WithContent(content ...Content) *ToolResult {
+	r.Content = append(r.Content, content...)
+	return r
+}
+
+// NewTextContent creates a text content block
+func NewTextContent(text string) Content {
+	return Content{Type: ContentTypeText, Text: text}
+}
+
+// NewImageContent creates an image content block from raw image bytes
+func NewImageContent(data []byte, mimeType string) Content {
+	return Content{Type: ContentTypeImage, Data: data, MIMEType: mimeType}
+}
+
+// NewAudioContent creates an audio content block from raw audio bytes
+func NewAudioContent(data []byte, mimeType string) Content {
+	return Content{Type: ContentTypeAudio, Data: data, MIMEType: mimeType}
+}
+
+// NewEmbeddedTextResource creates an embedded resource content block with text contents
+func NewEmbeddedTextResource(uri, mimeType, text string) Content {
+	return Content{Type: ContentTypeResource, URI: uri, MIMEType: mimeType, Text: text}
+}
+
+// NewEmbeddedBlobResource creates an embedded resource content block with binary contents
+func NewEmbeddedBlobResource(uri, mimeType string, data []byte) Content {
+	return Content{Type: ContentTypeResource, URI: uri, MIMEType: mimeType, Data: data}
+}
+
+// NewResourceLinkContent creates a content block linking to a resource the client can read
+func NewResourceLinkContent(uri, name, description, mimeType string) Content {
+	return Content{Type: ContentTypeResourceLink, URI: uri, Name: name, Description: description, MIMEType: mimeType}
+}
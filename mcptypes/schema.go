@@ -0,0 +1,292 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcptypes
+
+import (
+	"math"
+	"sort"
+)
+
+// Schema converts the parameter (and any nested Items or Properties) to a JSON Schema
+// (draft 2020-12) property definition. Exclusive bounds are emitted as numeric
+// exclusiveMinimum/exclusiveMaximum in place of minimum/maximum. Parameters without
+// a recognized Type are described as strings.
+func (p *Parameter) Schema() map[string]any {
+	schema := map[string]any{
+		"type": schemaType(p.Type),
+	}
+	if p.Description != "" {
+		schema["description"] = p.Description
+	}
+
+	// String validation
+	if p.Pattern != nil {
+		schema["pattern"] = *p.Pattern
+	}
+	if p.MinLength != nil {
+		schema["minLength"] = *p.MinLength
+	}
+	if p.MaxLength != nil {
+		schema["maxLength"] = *p.MaxLength
+	}
+	if p.Format != nil {
+		schema["format"] = *p.Format
+	}
+
+	// Numeric validation
+	if p.Minimum != nil {
+		if p.ExclusiveMinimum != nil && *p.ExclusiveMinimum {
+			schema["exclusiveMinimum"] = *p.Minimum
+		} else {
+			schema["minimum"] = *p.Minimum
+		}
+	}
+	if p.Maximum != nil {
+		if p.ExclusiveMaximum != nil && *p.ExclusiveMaximum {
+			schema["exclusiveMaximum"] = *p.Maximum
+		} else {
+			schema["maximum"] = *p.Maximum
+		}
+	}
+	if p.MultipleOf != nil {
+		schema["multipleOf"] = *p.MultipleOf
+	}
+
+	// Array validation
+	if p.Items != nil {
+		schema["items"] = p.Items.Schema()
+	}
+	if p.MinItems != nil {
+		schema["minItems"] = *p.MinItems
+	}
+	if p.MaxItems != nil {
+		schema["maxItems"] = *p.MaxItems
+	}
+	if p.UniqueItems != nil {
+		schema["uniqueItems"] = *p.UniqueItems
+	}
+
+	// Object validation
+	if p.Properties != nil {
+		properties, required := PropertiesSchema(p.Properties)
+		schema["properties"] = properties
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+	}
+	if p.AdditionalProperties != nil {
+		schema["additionalProperties"] = *p.AdditionalProperties
+	}
+
+	// Enum and default keep their original JSON types
+	if p.Enum != nil {
+		enum := make([]any, len(p.Enum))
+		copy(enum, p.Enum)
+		schema["enum"] = enum
+	}
+	if p.Default != nil {
+		schema["default"] = p.Default
+	}
+
+	return schema
+}
+
+// PropertiesSchema converts a set of named parameters to JSON Schema properties
+// and a sorted list of the required property names
+func PropertiesSchema(params map[string]*Parameter) (map[string]any, []string) {
+	properties := make(map[string]any, len(params))
+	var required []string
+	for name, param := range params {
+		properties[name] = param.Schema()
+		if param.Required {
+			required = append(required, name)
+		}
+	}
+	sort.Strings(required)
+	return properties, required
+}
+
+// ObjectSchema converts a parameter list (such as ToolDefinition.Parameters) to a
+// complete JSON Schema object. Required names are listed in declaration order.
+func ObjectSchema(params []*Parameter) map[string]any {
+	properties := make(map[string]any, len(params))
+	var required []string
+	for _, param := range params {
+		properties[param.Name] = param.Schema()
+		if param.Required {
+			required = append(required, param.Name)
+		}
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// ParametersFromSchema converts a JSON Schema object (such as the input schema of a tool
+// advertised by another MCP server) to a parameter list sorted by name. It is the inverse
+// of ObjectSchema for the keywords Parameter supports; other keywords (anyOf, $ref, ...)
+// are dropped, and a property without a type becomes a string.
+func ParametersFromSchema(schema map[string]any) []*Parameter {
+	properties, _ := schema["properties"].(map[string]any)
+	required := requiredNames(schema)
+
+	params := make([]*Parameter, 0, len(properties))
+	for name, property := range properties {
+		propertySchema, _ := property.(map[string]any)
+		params = append(params, ParameterFromSchema(name, propertySchema, required[name]))
+	}
+	sort.Slice(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+	return params
+}
+
+// ParameterFromSchema converts a JSON Schema property definition to a Parameter
+func ParameterFromSchema(name string, schema map[string]any, required bool) *Parameter {
+	p := &Parameter{
+		Name:     name,
+		Required: required,
+		Type:     typeFromSchema(schema),
+	}
+	p.Description, _ = schema["description"].(string)
+
+	// String validation
+	if pattern, ok := schema["pattern"].(string); ok {
+		p.Pattern = &pattern
+	}
+	if format, ok := schema["format"].(string); ok {
+		p.Format = &format
+	}
+	p.MinLength = intFromSchema(schema["minLength"])
+	p.MaxLength = intFromSchema(schema["maxLength"])
+
+	// Numeric validation, accepting both numeric (2020-12) and boolean (draft 4) exclusive bounds
+	p.Minimum = floatFromSchema(schema["minimum"])
+	p.Maximum = floatFromSchema(schema["maximum"])
+	if bound := floatFromSchema(schema["exclusiveMinimum"]); bound != nil {
+		p.Minimum = bound
+		p.WithExclusiveMinimum(true)
+	} else if exclusive, ok := schema["exclusiveMinimum"].(bool); ok {
+		p.ExclusiveMinimum = &exclusive
+	}
+	if bound := floatFromSchema(schema["exclusiveMaximum"]); bound != nil {
+		p.Maximum = bound
+		p.WithExclusiveMaximum(true)
+	} else if exclusive, ok := schema["exclusiveMaximum"].(bool); ok {
+		p.ExclusiveMaximum = &exclusive
+	}
+	p.MultipleOf = floatFromSchema(schema["multipleOf"])
+
+	// Array validation
+	if items, ok := schema["items"].(map[string]any); ok {
+		p.Items = ParameterFromSchema("", items, false)
+	}
+	p.MinItems = intFromSchema(schema["minItems"])
+	p.MaxItems = intFromSchema(schema["maxItems"])
+	if unique, ok := schema["uniqueItems"].(bool); ok {
+		p.UniqueItems = &unique
+	}
+
+	// Object validation
+	if properties, ok := schema["properties"].(map[string]any); ok {
+		nested := requiredNames(schema)
+		p.Properties = make(map[string]*Parameter, len(properties))
+		for propertyName, property := range properties {
+			propertySchema, _ := property.(map[string]any)
+			p.Properties[propertyName] = ParameterFromSchema(propertyName, propertySchema, nested[propertyName])
+		}
+	}
+	if additional, ok := schema["additionalProperties"].(bool); ok {
+		p.AdditionalProperties = &additional
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		p.Enum = enum
+	}
+	p.Default = schema["default"]
+
+	return p
+}
+
+// typeFromSchema returns the type of a JSON Schema, taking the first non-null type of a
+// type list and inferring object or array from the keywords present
+func typeFromSchema(schema map[string]any) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []any:
+		for _, entry := range t {
+			if name, ok := entry.(string); ok && name != "null" {
+				return name
+			}
+		}
+	}
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	if _, ok := schema["items"]; ok {
+		return "array"
+	}
+	return "string"
+}
+
+// requiredNames returns the set of names listed in a schema's required keyword
+func requiredNames(schema map[string]any) map[string]bool {
+	names := make(map[string]bool)
+	switch required := schema["required"].(type) {
+	case []any:
+		for _, name := range required {
+			if s, ok := name.(string); ok {
+				names[s] = true
+			}
+		}
+	case []string:
+		for _, name := range required {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// floatFromSchema returns a numeric schema keyword as a *float64
+func floatFromSchema(value any) *float64 {
+	var f float64
+	switch v := value.(type) {
+	case float64:
+		f = v
+	case int:
+		f = float64(v)
+	case int64:
+		f = float64(v)
+	default:
+		return nil
+	}
+	return &f
+}
+
+// intFromSchema returns a non-negative integer schema keyword as an *int
+func intFromSchema(value any) *int {
+	f := floatFromSchema(value)
+	if f == nil || *f < 0 || *f != math.Trunc(*f) {
+		return nil
+	}
+	n := int(*f)
+	return &n
+}
+
+// schemaType returns the JSON Schema type for a parameter, defaulting to string
+func schemaType(t string) string {
+	switch t {
+	case "string", "number", "integer", "boolean", "array", "object", "null":
+		return t
+	default:
+		return "string"
+	}
+}
@@ -0,0 +1,144 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcptypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// update rewrites the golden files with the current output: go test ./mcptypes -update
+var update = flag.Bool("update", false, "update golden files")
+
+// schemaCases are the parameter lists whose ObjectSchema output is locked in testdata
+var schemaCases = []struct {
+	name   string
+	params []*Parameter
+}{
+	{
+		name: "nested",
+		params: []*Parameter{
+			ObjectParam("customer", "Customer", true, map[string]*Parameter{
+				"name": StringParam("name", "Full name", true),
+				"address": ObjectParam("address", "Postal address", false, map[string]*Parameter{
+					"street": StringParam("street", "Street", true),
+					"city":   StringParam("city", "City", true),
+				}).WithAdditionalProperties(false),
+			}),
+			ArrayParam("items", "Order lines", true, ObjectParam("", "Order line", false, map[string]*Parameter{
+				"sku":      StringParam("sku", "Stock keeping unit", true),
+				"quantity": IntegerParam("quantity", "Quantity", true).WithMinimum(1),
+				"tags":     ArrayParam("tags", "Tags", false, StringParam("", "Tag", false)).WithUniqueItems(true),
+			})).WithMinItems(1).WithMaxItems(50),
+			ArrayParam("matrix", "Rows of numbers", false, ArrayParam("", "Row", false, NumberParam("", "Cell", false))),
+		},
+	},
+	{
+		name: "numeric",
+		params: []*Parameter{
+			IntegerParam("count", "Inclusive bounds", true).WithMinimum(0).WithMaximum(100),
+			NumberParam("ratio", "Exclusive bounds", false).WithMinimum(0).WithExclusiveMinimum(true).
+				WithMaximum(1).WithExclusiveMaximum(true),
+			NumberParam("step", "Multiple", false).WithMultipleOf(0.25),
+			NumberParam("floor", "Minimum only", false).WithMinimum(-273.15),
+		},
+	},
+	{
+		name: "strings",
+		params: []*Parameter{
+			StringParam("email", "Email address", true).WithFormat("email"),
+			StringParam("when", "Timestamp", false).WithFormat("date-time"),
+			StringParam("code", "Code", false).WithPattern(`^[A-Z]{3}$`).WithMinLength(3).WithMaxLength(3),
+		},
+	},
+	{
+		name: "enum_default",
+		params: []*Parameter{
+			StringParam("unit", "Units", false).WithEnum("metric", "imperial").WithDefault("metric"),
+			IntegerParam("days", "Forecast days", false).WithEnum(1, 3, 7).WithDefault(3),
+			BoolParam("verbose", "Verbose output", false).WithDefault(false),
+			ArrayParam("fields", "Fields", false, StringParam("", "Field", false).WithEnum("temp", "wind")).
+				WithDefault([]any{"temp"}),
+		},
+	},
+}
+
+func TestObjectSchemaGolden(t *testing.T) {
+	for _, tc := range schemaCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := json.MarshalIndent(ObjectSchema(tc.params), "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+
+			path := filepath.Join("testdata", tc.name+".golden")
+			if *update {
+				if err = os.WriteFile(path, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("schema does not match %s (run with -update to accept):\n%s", path, got)
+			}
+		})
+	}
+}
+
+func TestParametersFromSchemaRoundTrip(t *testing.T) {
+	for _, tc := range schemaCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schema := normalizeSchema(t, ObjectSchema(tc.params))
+			again := normalizeSchema(t, ObjectSchema(ParametersFromSchema(schema)))
+
+			// Required names are sorted by ParametersFromSchema, so compare them as sets
+			sortRequired(schema)
+			sortRequired(again)
+			if !reflect.DeepEqual(schema, again) {
+				t.Errorf("round trip changed the schema:\n got %v\nwant %v", again, schema)
+			}
+		})
+	}
+}
+
+// normalizeSchema converts a schema to its decoded JSON form so that values compare equal
+// regardless of their Go types
+func normalizeSchema(t *testing.T, schema map[string]any) map[string]any {
+	t.Helper()
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
+
+// sortRequired sorts the top-level required list in place
+func sortRequired(schema map[string]any) {
+	required, _ := schema["required"].([]any)
+	names := make([]string, len(required))
+	for i, name := range required {
+		names[i], _ = name.(string)
+	}
+	slices.Sort(names)
+	for i, name := range names {
+		required[i] = name
+	}
+}
@@ -0,0 +1,224 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcptypes
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParamsFromStruct derives a parameter list from the exported fields of struct type T.
+// It panics if T is not a struct (or pointer to struct), since that is a programming error.
+//
+// Supported struct tags:
+//
+//	json:"name,omitempty"   parameter name ("-" skips the field)
+//	description:"..."       parameter description
+//	required:"true"         parameter is required
+//	enum:"a,b,c"            allowed values, parsed according to the field type (item values for slices)
+//	min:"1" max:"10"        minimum/maximum for numbers, length for strings, item count for slices
+//	pattern:"^[a-z]+$"      regex pattern for strings
+//	format:"email"          string format
+//	default:"..."           default value, parsed according to the field type
+//
+// Nested structs become object parameters and slices become array parameters.
+// Fields of interface, channel or function type are skipped.
+func ParamsFromStruct[T any]() []*Parameter {
+	t := reflect.TypeFor[T]()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("mcptypes: ParamsFromStruct requires a struct type, got %s", t))
+	}
+	return structParams(t, map[reflect.Type]bool{})
+}
+
+// timeType is handled as a date-time string rather than a struct
+var timeType = reflect.TypeFor[time.Time]()
+
+// structParams builds parameters for each exported field of a struct type
+func structParams(t reflect.Type, visiting map[reflect.Type]bool) []*Parameter {
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	var params []*Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		// Skip unexported fields
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		// Determine the name from the json tag
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			} else if field.Anonymous {
+				name = ""
+			}
+		} else if field.Anonymous {
+			name = ""
+		}
+
+		// Flatten embedded structs the same way encoding/json does
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if name == "" && fieldType.Kind() == reflect.Struct {
+			if !visiting[fieldType] { // A struct that embeds itself adds no further fields
+				params = append(params, structParams(fieldType, visiting)...)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		param := typeParam(field.Type, visiting)
+		if param == nil {
+			continue
+		}
+		param.Name = name
+		param.Description = field.Tag.Get("description")
+		param.Required = field.Tag.Get("required") == "true"
+		applyFieldTags(param, field)
+		params = append(params, param)
+	}
+	return params
+}
+
+// typeParam builds a parameter describing a Go type, or nil if the type is unsupported
+func typeParam(t reflect.Type, visiting map[reflect.Type]bool) *Parameter {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return (&Parameter{Type: "string"}).WithFormat("date-time")
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Parameter{Type: "string"}
+	case reflect.Bool:
+		return &Parameter{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Parameter{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Parameter{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		// encoding/json encodes []byte as a base64 string
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Parameter{Type: "string"}
+		}
+		return &Parameter{Type: "array", Items: typeParam(t.Elem(), visiting)}
+	case reflect.Map:
+		return &Parameter{Type: "object"}
+	case reflect.Struct:
+		param := &Parameter{Type: "object", Properties: map[string]*Parameter{}}
+		if visiting[t] {
+			// Recursive type; describe it as an open object
+			return param
+		}
+		for _, prop := range structParams(t, visiting) {
+			param.Properties[prop.Name] = prop
+		}
+		return param
+	default:
+		return nil
+	}
+}
+
+// applyFieldTags applies validation tags to a parameter
+func applyFieldTags(param *Parameter, field reflect.StructField) {
+	if pattern, ok := field.Tag.Lookup("pattern"); ok {
+		param.WithPattern(pattern)
+	}
+	if format, ok := field.Tag.Lookup("format"); ok {
+		param.WithFormat(format)
+	}
+	if enum, ok := field.Tag.Lookup("enum"); ok {
+		// The values of a slice field constrain its items
+		target := param
+		if param.Type == "array" && param.Items != nil {
+			target = param.Items
+		}
+		for _, value := range strings.Split(enum, ",") {
+			target.Enum = append(target.Enum, parseTagValue(target.Type, strings.TrimSpace(value)))
+		}
+	}
+	if def, ok := field.Tag.Lookup("default"); ok {
+		param.Default = parseTagValue(param.Type, def)
+	}
+	if min, ok := field.Tag.Lookup("min"); ok {
+		applyBound(param, min, true)
+	}
+	if max, ok := field.Tag.Lookup("max"); ok {
+		applyBound(param, max, false)
+	}
+}
+
+// applyBound applies a min or max tag according to the parameter type
+func applyBound(param *Parameter, value string, isMin bool) {
+	switch param.Type {
+	case "number", "integer":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			if isMin {
+				param.WithMinimum(f)
+			} else {
+				param.WithMaximum(f)
+			}
+		}
+	case "string":
+		if n, err := strconv.Atoi(value); err == nil {
+			if isMin {
+				param.WithMinLength(n)
+			} else {
+				param.WithMaxLength(n)
+			}
+		}
+	case "array":
+		if n, err := strconv.Atoi(value); err == nil {
+			if isMin {
+				param.WithMinItems(n)
+			} else {
+				param.WithMaxItems(n)
+			}
+		}
+	}
+}
+
+// parseTagValue converts a tag value to the Go type matching the parameter type.
+// Values that cannot be parsed are kept as strings.
+func parseTagValue(paramType, value string) any {
+	switch paramType {
+	case "integer":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
@@ -0,0 +1,198 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcptypes
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type taggedArgs struct {
+	Query    string   `json:"query" description:"Search text" required:"true" min:"1" max:"100" pattern:"^\\w+$"`
+	Limit    int      `json:"limit,omitempty" min:"1" max:"50" default:"10"`
+	Sort     string   `json:"sort" enum:"asc, desc" default:"asc"`
+	Ratio    float64  `json:"ratio" enum:"0.5,1"`
+	Tags     []string `json:"tags" enum:"red,green" min:"1"`
+	Email    string   `json:"email" format:"email"`
+	Internal string   `json:"-"`
+	hidden   string
+}
+
+type embeddedBase struct {
+	ID string `json:"id" required:"true"`
+}
+
+type embeddedExtra struct {
+	Note string `json:"note"`
+}
+
+type embeddedArgs struct {
+	embeddedBase
+	*embeddedExtra
+	Named embeddedBase `json:"named"`
+	Name  string
+}
+
+type optionalArgs struct {
+	Count   *int           `json:"count,omitempty"`
+	When    time.Time      `json:"when"`
+	Until   *time.Time     `json:"until"`
+	Data    []byte         `json:"data"`
+	Options map[string]any `json:"options"`
+	Matrix  [][]float64    `json:"matrix"`
+	Skipped func()         `json:"skipped"`
+}
+
+type treeNode struct {
+	Name     string     `json:"name"`
+	Children []treeNode `json:"children"`
+	Parent   *treeNode  `json:"parent"`
+}
+
+type selfEmbedding struct {
+	*selfEmbedding
+	X int `json:"x"`
+}
+
+// schemaJSON returns the object schema of a parameter list, decoded from JSON
+func schemaJSON(t *testing.T, params []*Parameter) any {
+	t.Helper()
+	data, err := json.Marshal(ObjectSchema(params))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema any
+	if err = json.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestParamsFromStruct(t *testing.T) {
+	tests := []struct {
+		name   string
+		params func() []*Parameter
+		want   string
+	}{
+		{
+			name:   "tags",
+			params: ParamsFromStruct[taggedArgs],
+			want: `{"type": "object", "required": ["query"], "properties": {
+				"query": {"type": "string", "description": "Search text", "minLength": 1, "maxLength": 100, "pattern": "^\\w+$"},
+				"limit": {"type": "integer", "minimum": 1, "maximum": 50, "default": 10},
+				"sort": {"type": "string", "enum": ["asc", "desc"], "default": "asc"},
+				"ratio": {"type": "number", "enum": [0.5, 1]},
+				"tags": {"type": "array", "minItems": 1, "items": {"type": "string", "enum": ["red", "green"]}},
+				"email": {"type": "string", "format": "email"}}}`,
+		},
+		{
+			name:   "embedded structs",
+			params: ParamsFromStruct[*embeddedArgs],
+			want: `{"type": "object", "required": ["id"], "properties": {
+				"id": {"type": "string"},
+				"note": {"type": "string"},
+				"named": {"type": "object", "required": ["id"], "properties": {"id": {"type": "string"}}},
+				"Name": {"type": "string"}}}`,
+		},
+		{
+			name:   "pointer and optional fields",
+			params: ParamsFromStruct[optionalArgs],
+			want: `{"type": "object", "properties": {
+				"count": {"type": "integer"},
+				"when": {"type": "string", "format": "date-time"},
+				"until": {"type": "string", "format": "date-time"},
+				"data": {"type": "string"},
+				"options": {"type": "object"},
+				"matrix": {"type": "array", "items": {"type": "array", "items": {"type": "number"}}}}}`,
+		},
+		{
+			name:   "recursive type",
+			params: ParamsFromStruct[treeNode],
+			want: `{"type": "object", "properties": {
+				"name": {"type": "string"},
+				"children": {"type": "array", "items": {"type": "object", "properties": {}}},
+				"parent": {"type": "object", "properties": {}}}}`,
+		},
+		{
+			name:   "struct embedding itself",
+			params: ParamsFromStruct[selfEmbedding],
+			want:   `{"type": "object", "properties": {"x": {"type": "integer"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var want any
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if got := schemaJSON(t, tt.params()); !reflect.DeepEqual(got, want) {
+				data, _ := json.MarshalIndent(got, "", "  ")
+				t.Errorf("schema =\n%s", data)
+			}
+		})
+	}
+}
+
+func TestParamsFromStructRejectsNonStruct(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("ParamsFromStruct[int] did not panic")
+		}
+	}()
+	ParamsFromStruct[int]()
+}
+
+func TestTypedTool(t *testing.T) {
+	type args struct {
+		Query string    `json:"query" required:"true"`
+		Limit *int      `json:"limit"`
+		Tags  []string  `json:"tags"`
+		When  time.Time `json:"when"`
+		embeddedBase
+	}
+
+	var got args
+	tool := TypedTool("search", "Search", func(_ context.Context, a args) (*ToolResult, error) {
+		got = a
+		return NewToolResult(NewTextContent(a.Query)), nil
+	})
+	if tool.Name != "search" || len(tool.Parameters) != 5 {
+		t.Fatalf("tool = %s with %d parameters, want search with 5", tool.Name, len(tool.Parameters))
+	}
+
+	options := map[string]any{
+		"query": "go",
+		"limit": float64(3),
+		"tags":  []any{"a", "b"},
+		"when":  "2025-01-02T03:04:05Z",
+		"id":    "x1",
+	}
+	result, err := tool.HandlerResult(context.Background(), ToolRequest{}, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Content[0].Text != "go" {
+		t.Errorf("result = %q, want go", result.Content[0].Text)
+	}
+	want := args{
+		Query:        "go",
+		Limit:        &[]int{3}[0],
+		Tags:         []string{"a", "b"},
+		When:         time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		embeddedBase: embeddedBase{ID: "x1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("handler received %+v, want %+v", got, want)
+	}
+
+	if _, err = tool.HandlerResult(context.Background(), ToolRequest{}, map[string]any{"query": 42}); err == nil {
+		t.Error("arguments of the wrong type were decoded")
+	}
+}
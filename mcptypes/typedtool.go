@@ -0,0 +1,46 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcptypes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// TypedToolHandler defines the function signature for handlers that receive decoded arguments
+type TypedToolHandler[T any] func(ctx context.Context, args T) (*ToolResult, error)
+
+// TypedTool creates a ToolDefinition whose parameters are derived from struct type T
+// (see ParamsFromStruct) and whose handler receives the arguments decoded into T.
+// Set Hints or OutputSchema on the returned definition as needed.
+func TypedTool[T any](name, description string, handler TypedToolHandler[T]) ToolDefinition {
+	return ToolDefinition{
+		Name:        name,
+		Description: description,
+		Parameters:  ParamsFromStruct[T](),
+		HandlerResult: func(ctx context.Context, req ToolRequest, options map[string]any) (*ToolResult, error) {
+			args, err := DecodeArguments[T](options)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, args)
+		},
+	}
+}
+
+// DecodeArguments decodes tool arguments into a value of type T using its json tags
+func DecodeArguments[T any](options map[string]any) (T, error) {
+	var args T
+	data, err := json.Marshal(options)
+	if err != nil {
+		return args, fmt.Errorf("unable to encode arguments: %w", err)
+	}
+	if err = json.Unmarshal(data, &args); err != nil {
+		return args, fmt.Errorf("invalid arguments: %w", err)
+	}
+	return args, nil
+}
@@ -0,0 +1,20 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package openapi
+
+import "github.com/PivotLLM/MCPLaunchPad/internal/httptool"
+
+// Auth adds credentials to a request before it is sent to the API
+type Auth = httptool.Auth
+
+// Authentication schemes for WithAuth
+var (
+	BearerAuth      = httptool.BearerAuth      // Static token in the Authorization header
+	TokenAuth       = httptool.TokenAuth       // Token obtained for each request, in the Authorization header
+	APIKeyAuth      = httptool.APIKeyAuth      // API key in the named header
+	APIKeyQueryAuth = httptool.APIKeyQueryAuth // API key as the named query parameter
+	BasicAuth       = httptool.BasicAuth       // HTTP basic authentication
+)
@@ -0,0 +1,410 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// methods lists the HTTP methods of a path item in the order their operations are generated
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// invalidNameChars matches characters that are not allowed in tool names
+var invalidNameChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// operation is an API operation with its parameters resolved
+type operation struct {
+	name        string // Tool name without prefix
+	method      string // Upper case HTTP method
+	path        string // Path template, e.g. /widgets/{id}
+	description string
+	params      []parameter
+	body        *requestBody
+}
+
+// parameter is a path, query or header parameter of an operation
+type parameter struct {
+	name     string // Tool parameter name
+	wireName string // Name in the request
+	in       string // "path", "query" or "header"
+	schema   map[string]any
+	required bool
+	doc      string
+}
+
+// requestBody describes how the tool arguments are sent as the request body
+type requestBody struct {
+	contentType string
+	required    bool
+	flatten     bool           // Object properties are top-level tool parameters
+	properties  []string       // Names of the flattened properties
+	param       string         // Tool parameter holding the whole body, if not flattened
+	schema      map[string]any // Schema of the body
+	doc         string
+}
+
+// parseDocument decodes an OpenAPI 3 document. YAML is a superset of JSON, so both are
+// decoded as YAML and then normalized to the types encoding/json produces.
+func parseDocument(data []byte) (map[string]any, error) {
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("unable to parse OpenAPI document: %w", err)
+	}
+	normalized, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse OpenAPI document: %w", err)
+	}
+	var doc map[string]any
+	if err = json.Unmarshal(normalized, &doc); err != nil {
+		return nil, fmt.Errorf("OpenAPI document is not an object: %w", err)
+	}
+
+	version, _ := doc["openapi"].(string)
+	if !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version '%s'; only OpenAPI 3 is supported", version)
+	}
+	return doc, nil
+}
+
+// serverURL returns the URL of the first server in the document, with server variables
+// replaced by their defaults
+func serverURL(doc map[string]any) (string, error) {
+	servers, _ := doc["servers"].([]any)
+	if len(servers) == 0 {
+		return "", fmt.Errorf("OpenAPI document lists no servers; use WithBaseURL()")
+	}
+	server, _ := servers[0].(map[string]any)
+	serverURL, _ := server["url"].(string)
+
+	variables, _ := server["variables"].(map[string]any)
+	for name, variable := range variables {
+		value, _ := variable.(map[string]any)
+		serverURL = strings.ReplaceAll(serverURL, "{"+name+"}", fmt.Sprint(value["default"]))
+	}
+	return serverURL, nil
+}
+
+// parseOperations returns the operations of the document sorted by path and method
+func parseOperations(doc map[string]any, logger mcptypes.Logger) ([]operation, error) {
+	r := &resolver{doc: doc, logger: logger}
+	paths, _ := r.resolve(doc["paths"], nil).(map[string]any)
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("OpenAPI document has no paths")
+	}
+
+	pathNames := make([]string, 0, len(paths))
+	for path := range paths {
+		pathNames = append(pathNames, path)
+	}
+	sort.Strings(pathNames)
+
+	var operations []operation
+	names := make(map[string]bool)
+	for _, path := range pathNames {
+		item, _ := paths[path].(map[string]any)
+		for _, method := range methods {
+			spec, ok := item[method].(map[string]any)
+			if !ok {
+				continue
+			}
+			op, err := newOperation(method, path, item, spec)
+			if err != nil {
+				logger.Warningf("OpenAPI: skipping %s %s: %v", strings.ToUpper(method), path, err)
+				continue
+			}
+			if names[op.name] {
+				logger.Warningf("OpenAPI: skipping %s %s: tool name '%s' is already used", op.method, path, op.name)
+				continue
+			}
+			names[op.name] = true
+			operations = append(operations, op)
+		}
+	}
+	return operations, nil
+}
+
+// newOperation builds an operation from its specification and the path item containing it
+func newOperation(method, path string, item, spec map[string]any) (operation, error) {
+	op := operation{
+		method:      strings.ToUpper(method),
+		path:        path,
+		name:        operationName(method, path, spec),
+		description: operationDescription(spec),
+	}
+
+	// Operation parameters override path item parameters with the same name and location
+	specs := parameterSpecs(item["parameters"])
+	for key, value := range parameterSpecs(spec["parameters"]) {
+		specs[key] = value
+	}
+	keys := make([]string, 0, len(specs))
+	for key := range specs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	used := make(map[string]bool)
+	for _, key := range keys {
+		p := specs[key]
+		wireName, _ := p["name"].(string)
+		in, _ := p["in"].(string)
+		if in == "cookie" {
+			continue
+		}
+		if in != "path" && in != "query" && in != "header" {
+			return op, fmt.Errorf("parameter '%s' has unknown location '%s'", wireName, in)
+		}
+
+		name := wireName
+		if used[name] {
+			name = in + "_" + wireName
+		}
+		used[name] = true
+
+		schema, _ := p["schema"].(map[string]any)
+		required, _ := p["required"].(bool)
+		doc, _ := p["description"].(string)
+		op.params = append(op.params, parameter{
+			name:     name,
+			wireName: wireName,
+			in:       in,
+			schema:   schema,
+			required: required || in == "path",
+			doc:      doc,
+		})
+	}
+
+	body, err := newRequestBody(spec["requestBody"], used)
+	if err != nil {
+		return op, err
+	}
+	op.body = body
+	return op, nil
+}
+
+// parameterSpecs returns parameter specifications keyed by location and name
+func parameterSpecs(value any) map[string]map[string]any {
+	list, _ := value.([]any)
+	specs := make(map[string]map[string]any, len(list))
+	for _, entry := range list {
+		p, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		specs[fmt.Sprintf("%v:%v", p["in"], p["name"])] = p
+	}
+	return specs
+}
+
+// newRequestBody describes how to send the request body. The properties of a JSON or form
+// body are flattened into top-level tool parameters unless they clash with a parameter name;
+// other bodies are passed as a single "body" parameter.
+func newRequestBody(value any, used map[string]bool) (*requestBody, error) {
+	spec, ok := value.(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	content, _ := spec["content"].(map[string]any)
+	contentType := selectContentType(content)
+	if contentType == "" {
+		return nil, fmt.Errorf("request body has no content type")
+	}
+
+	body := &requestBody{contentType: contentType}
+	body.required, _ = spec["required"].(bool)
+	body.doc, _ = spec["description"].(string)
+	media, _ := content[contentType].(map[string]any)
+	body.schema, _ = media["schema"].(map[string]any)
+
+	if isJSON(contentType) || contentType == "application/x-www-form-urlencoded" {
+		properties, _ := body.schema["properties"].(map[string]any)
+		flatten := len(properties) > 0
+		for name := range properties {
+			if used[name] {
+				flatten = false
+			}
+		}
+		if flatten {
+			body.flatten = true
+			for name := range properties {
+				body.properties = append(body.properties, name)
+			}
+			sort.Strings(body.properties)
+			return body, nil
+		}
+	}
+
+	body.param = "body"
+	if used[body.param] {
+		body.param = "request_body"
+	}
+	return body, nil
+}
+
+// selectContentType picks the request content type, preferring JSON
+func selectContentType(content map[string]any) string {
+	types := make([]string, 0, len(content))
+	for contentType := range content {
+		types = append(types, contentType)
+	}
+	sort.Strings(types)
+
+	for _, contentType := range types {
+		if isJSON(contentType) {
+			return contentType
+		}
+	}
+	for _, contentType := range types {
+		if contentType == "application/x-www-form-urlencoded" {
+			return contentType
+		}
+	}
+	if len(types) > 0 {
+		return types[0]
+	}
+	return ""
+}
+
+// isJSON reports whether a media type is JSON, including types such as application/merge-patch+json
+func isJSON(contentType string) bool {
+	return contentType == "application/json" || strings.HasSuffix(contentType, "+json")
+}
+
+// operationName returns the operation ID, or a name derived from the method and path, made
+// safe for use as a tool name
+func operationName(method, path string, spec map[string]any) string {
+	name, _ := spec["operationId"].(string)
+	if name == "" {
+		// e.g. "delete /widgets/{id}" becomes delete_widgets_id
+		name = method + "_" + strings.Join(strings.FieldsFunc(path, func(r rune) bool {
+			return r == '/' || r == '{' || r == '}'
+		}), "_")
+	}
+	name = strings.Trim(invalidNameChars.ReplaceAllString(name, "_"), "_")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// operationDescription combines the summary and description of an operation
+func operationDescription(spec map[string]any) string {
+	summary, _ := spec["summary"].(string)
+	description, _ := spec["description"].(string)
+	switch {
+	case summary == "":
+		return description
+	case description == "" || description == summary:
+		return summary
+	default:
+		return summary + "\n\n" + description
+	}
+}
+
+// resolver expands local $ref pointers ("#/components/...") within a document
+type resolver struct {
+	doc    map[string]any
+	logger mcptypes.Logger
+}
+
+// resolve returns a copy of value with local references replaced by their targets. A
+// reference back to a schema that is being expanded becomes an unconstrained object.
+func (r *resolver) resolve(value any, expanding []string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok {
+			for _, active := range expanding {
+				if active == ref {
+					return map[string]any{"type": "object"}
+				}
+			}
+			target, err := r.lookup(ref)
+			if err != nil {
+				r.logger.Warningf("OpenAPI: %v", err)
+				return map[string]any{}
+			}
+			return r.resolve(target, append(expanding, ref))
+		}
+		resolved := make(map[string]any, len(v))
+		for key, item := range v {
+			resolved[key] = r.resolve(item, expanding)
+		}
+		return mergeAllOf(resolved)
+	case []any:
+		resolved := make([]any, len(v))
+		for i, item := range v {
+			resolved[i] = r.resolve(item, expanding)
+		}
+		return resolved
+	default:
+		return value
+	}
+}
+
+// mergeAllOf combines the properties and required lists of an allOf composition of object
+// schemas into the schema itself, which is how allOf is commonly used to extend a schema
+func mergeAllOf(schema map[string]any) map[string]any {
+	parts, ok := schema["allOf"].([]any)
+	if !ok {
+		return schema
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	if properties == nil {
+		properties = make(map[string]any)
+	}
+	required, _ := schema["required"].([]any)
+	for _, part := range parts {
+		object, ok := part.(map[string]any)
+		if !ok {
+			return schema
+		}
+		partProperties, _ := object["properties"].(map[string]any)
+		for name, property := range partProperties {
+			properties[name] = property
+		}
+		partRequired, _ := object["required"].([]any)
+		required = append(required, partRequired...)
+		if description, ok := object["description"].(string); ok && schema["description"] == nil {
+			schema["description"] = description
+		}
+	}
+
+	delete(schema, "allOf")
+	schema["type"] = "object"
+	schema["properties"] = properties
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// lookup returns the target of a local JSON pointer reference
+func (r *resolver) lookup(ref string) (any, error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported reference '%s'; only local references are supported", ref)
+	}
+	var current any = r.doc
+	for _, token := range strings.Split(ref[2:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unresolvable reference '%s'", ref)
+		}
+		if current, ok = object[token]; !ok {
+			return nil, fmt.Errorf("unresolvable reference '%s'", ref)
+		}
+	}
+	return current, nil
+}
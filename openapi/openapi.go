@@ -0,0 +1,187 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+// Package openapi provides a tool provider that loads an OpenAPI 3 document and exposes
+// each operation as an MCP tool that calls the API.
+package openapi
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/PivotLLM/MCPLaunchPad/internal/httptool"
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// Ensure Provider implements the mcptypes.ToolProvider interface
+var _ mcptypes.ToolProvider = (*Provider)(nil)
+
+// Option defines a function type for configuring the Provider
+type Option func(*Provider)
+
+// Provider is a tool provider with one tool per operation of an OpenAPI 3 document
+type Provider struct {
+	logger          mcptypes.Logger
+	baseURL         string
+	httpClient      *http.Client
+	auth            Auth
+	prefix          string
+	operations      map[string]bool
+	retries         int
+	retryWait       time.Duration
+	maxResponseSize int64
+
+	client *httptool.Client
+	tools  []mcptypes.ToolDefinition
+}
+
+// WithLogger sets the logger
+func WithLogger(logger mcptypes.Logger) Option {
+	return func(p *Provider) {
+		p.logger = logger
+	}
+}
+
+// WithBaseURL sets the URL the operation paths are relative to, overriding the first
+// server listed in the document
+func WithBaseURL(baseURL string) Option {
+	return func(p *Provider) {
+		p.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to call the API (default: 30s timeout)
+func WithHTTPClient(client *http.Client) Option {
+	return func(p *Provider) {
+		p.httpClient = client
+	}
+}
+
+// WithAuth sets how requests are authenticated, for example with BearerAuth or APIKeyAuth
+func WithAuth(auth Auth) Option {
+	return func(p *Provider) {
+		p.auth = auth
+	}
+}
+
+// WithToolPrefix sets a prefix for the generated tool names, e.g. "widgets_"
+func WithToolPrefix(prefix string) Option {
+	return func(p *Provider) {
+		p.prefix = prefix
+	}
+}
+
+// WithOperations limits the tools to the named operations. Names are operation IDs, or the
+// generated tool names (without prefix) of operations that have none.
+func WithOperations(names ...string) Option {
+	return func(p *Provider) {
+		if p.operations == nil {
+			p.operations = make(map[string]bool)
+		}
+		for _, name := range names {
+			p.operations[name] = true
+		}
+	}
+}
+
+// WithRetries retries idempotent operations that fail with a network error or a 429, 502, 503
+// or 504 status, up to retries times. The delay starts at wait and doubles after each attempt,
+// unless the API asks for a different one with Retry-After (default: no retries).
+func WithRetries(retries int, wait time.Duration) Option {
+	return func(p *Provider) {
+		p.retries = retries
+		p.retryWait = wait
+	}
+}
+
+// WithMaxResponseSize limits the size of the response bodies returned to the client, truncating
+// longer ones (default: 1 MiB)
+func WithMaxResponseSize(size int64) Option {
+	return func(p *Provider) {
+		p.maxResponseSize = size
+	}
+}
+
+// New creates a provider from an OpenAPI 3 document in JSON or YAML
+func New(document []byte, options ...Option) (*Provider, error) {
+	p := &Provider{
+		httpClient:      &http.Client{Timeout: 30 * time.Second},
+		retryWait:       500 * time.Millisecond,
+		maxResponseSize: 1 << 20,
+	}
+	for _, opt := range options {
+		opt(p)
+	}
+	if p.logger == nil {
+		p.logger = mcptypes.NoopLogger{}
+	}
+	p.client = &httptool.Client{
+		HTTPClient:      p.httpClient,
+		Auth:            p.auth,
+		Retries:         p.retries,
+		RetryWait:       p.retryWait,
+		MaxResponseSize: p.maxResponseSize,
+		Logger:          p.logger,
+		Name:            "OpenAPI",
+	}
+
+	doc, err := parseDocument(document)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.baseURL == "" {
+		if p.baseURL, err = serverURL(doc); err != nil {
+			return nil, err
+		}
+	}
+	base, err := url.Parse(p.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if !base.IsAbs() {
+		return nil, fmt.Errorf("base URL '%s' is not absolute; use WithBaseURL()", p.baseURL)
+	}
+
+	operations, err := parseOperations(doc, p.logger)
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range operations {
+		if p.operations != nil && !p.operations[op.name] {
+			continue
+		}
+		p.tools = append(p.tools, p.toolDefinition(op))
+	}
+	p.logger.Infof("OpenAPI: loaded %d of %d operations", len(p.tools), len(operations))
+	return p, nil
+}
+
+// NewFromFile creates a provider from an OpenAPI 3 document file
+func NewFromFile(path string, options ...Option) (*Provider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read OpenAPI document: %w", err)
+	}
+	return New(data, options...)
+}
+
+// NewFromFS creates a provider from an OpenAPI 3 document in a file system such as an embed.FS
+func NewFromFS(fsys fs.FS, path string, options ...Option) (*Provider, error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read OpenAPI document: %w", err)
+	}
+	return New(data, options...)
+}
+
+// RegisterTools returns one tool per operation
+func (p *Provider) RegisterTools() []mcptypes.ToolDefinition {
+	return p.tools
+}
@@ -0,0 +1,109 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package openapi_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+	"github.com/PivotLLM/MCPLaunchPad/openapi"
+)
+
+const widgetDocument = `
+openapi: 3.0.0
+info: {title: Widgets, version: "1"}
+paths:
+  /widgets:
+    get:
+      operationId: list_widgets
+    post:
+      operationId: create_widget
+`
+
+// callTool calls a tool of the provider directly
+func callTool(t *testing.T, p *openapi.Provider, name string) *mcptypes.ToolResult {
+	t.Helper()
+	for _, tool := range p.RegisterTools() {
+		if tool.Name == name {
+			result, err := tool.HandlerResult(context.Background(), mcptypes.ToolRequest{}, map[string]any{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return result
+		}
+	}
+	t.Fatalf("no tool %s", name)
+	return nil
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name    string
+		tool    string
+		retries int
+		want    string
+		calls   int32
+	}{
+		{"retried", "list_widgets", 2, "GET ok", 3},
+		{"retries exhausted", "list_widgets", 1, "HTTP 503 Service Unavailable: busy", 2},
+		{"no retries by default", "list_widgets", -1, "HTTP 503 Service Unavailable: busy", 1},
+		{"POST not retried", "create_widget", 2, "HTTP 503 Service Unavailable: busy", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) <= 2 {
+					http.Error(w, "busy", http.StatusServiceUnavailable)
+					return
+				}
+				_, _ = io.WriteString(w, r.Method+" ok")
+			}))
+			defer api.Close()
+
+			options := []openapi.Option{openapi.WithBaseURL(api.URL)}
+			if tt.retries >= 0 {
+				options = append(options, openapi.WithRetries(tt.retries, time.Millisecond))
+			}
+			p, err := openapi.New([]byte(widgetDocument), options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result := callTool(t, p, tt.tool)
+			if text := result.Content[0].Text; text != tt.want {
+				t.Errorf("result = %q, want %q", text, tt.want)
+			}
+			if got := calls.Load(); got != tt.calls {
+				t.Errorf("API called %d times, want %d", got, tt.calls)
+			}
+		})
+	}
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, strings.Repeat("x", 100))
+	}))
+	defer api.Close()
+
+	p, err := openapi.New([]byte(widgetDocument), openapi.WithBaseURL(api.URL), openapi.WithMaxResponseSize(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "xxxxxxxxxx\n[response truncated to 10 bytes]"
+	if text := callTool(t, p, "list_widgets").Content[0].Text; text != want {
+		t.Errorf("result = %q, want %q", text, want)
+	}
+}
@@ -0,0 +1,189 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PivotLLM/MCPLaunchPad/internal/httptool"
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// toolDefinition creates the tool that performs an operation
+func (p *Provider) toolDefinition(op operation) mcptypes.ToolDefinition {
+	var params []*mcptypes.Parameter
+	for _, param := range op.params {
+		parameter := mcptypes.ParameterFromSchema(param.name, param.schema, param.required)
+		if param.doc != "" {
+			parameter.Description = param.doc
+		}
+		params = append(params, parameter)
+	}
+
+	if body := op.body; body != nil {
+		if body.flatten {
+			for _, param := range mcptypes.ParametersFromSchema(body.schema) {
+				param.Required = param.Required && body.required
+				params = append(params, param)
+			}
+		} else {
+			param := mcptypes.ParameterFromSchema(body.param, body.schema, body.required)
+			if body.schema == nil || !isJSON(body.contentType) {
+				param.Type = "string"
+			}
+			if body.doc != "" {
+				param.Description = body.doc
+			}
+			if param.Description == "" {
+				param.Description = "Request body (" + body.contentType + ")"
+			}
+			params = append(params, param)
+		}
+	}
+
+	return mcptypes.ToolDefinition{
+		Name:        p.prefix + op.name,
+		Description: op.description,
+		Parameters:  params,
+		Hints:       httptool.MethodHints(op.method),
+		HandlerResult: func(ctx context.Context, _ mcptypes.ToolRequest, options map[string]any) (*mcptypes.ToolResult, error) {
+			return p.call(ctx, op, options)
+		},
+	}
+}
+
+// call performs an operation with the tool arguments. Image responses are returned as
+// image content and everything else as text.
+func (p *Provider) call(ctx context.Context, op operation, options map[string]any) (*mcptypes.ToolResult, error) {
+	req, err := p.newRequest(ctx, op, options)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", op.method, op.path, err)
+	}
+	if !resp.OK() {
+		return resp.ErrorResult(), nil
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, "image/") && !resp.Truncated {
+		return mcptypes.NewToolResult(mcptypes.NewImageContent(resp.Body, contentType)), nil
+	}
+	return resp.TextResult(), nil
+}
+
+// newRequest builds the HTTP request for an operation from the tool arguments
+func (p *Provider) newRequest(ctx context.Context, op operation, options map[string]any) (*http.Request, error) {
+	path := op.path
+	query := url.Values{}
+	header := http.Header{}
+	for _, param := range op.params {
+		value, ok := options[param.name]
+		if !ok || value == nil {
+			if param.required {
+				return nil, fmt.Errorf("missing required parameter: %s", param.name)
+			}
+			continue
+		}
+
+		switch param.in {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+param.wireName+"}", url.PathEscape(httptool.FormatValue(value)))
+		case "query":
+			if list, ok := value.([]any); ok {
+				for _, item := range list {
+					query.Add(param.wireName, httptool.FormatValue(item))
+				}
+			} else {
+				query.Set(param.wireName, httptool.FormatValue(value))
+			}
+		case "header":
+			header.Set(param.wireName, httptool.FormatValue(value))
+		}
+	}
+
+	target, err := httptool.ResolveURL(p.baseURL, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := encodeBody(op.body, options)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, op.method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s request: %w", op.method, err)
+	}
+	req.Header = header
+	if body != nil {
+		req.Header.Set("Content-Type", op.body.contentType)
+	}
+	req.Header.Set("Accept", "application/json, */*;q=0.8")
+	return req, nil
+}
+
+// encodeBody returns the request body built from the tool arguments, or nil if the
+// operation has no body or no body arguments were given
+func encodeBody(body *requestBody, options map[string]any) (io.Reader, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	var value any
+	if body.flatten {
+		fields := make(map[string]any)
+		for _, name := range body.properties {
+			if v, ok := options[name]; ok {
+				fields[name] = v
+			}
+		}
+		if len(fields) == 0 && !body.required {
+			return nil, nil
+		}
+		value = fields
+	} else {
+		v, ok := options[body.param]
+		if !ok {
+			if body.required {
+				return nil, fmt.Errorf("missing required parameter: %s", body.param)
+			}
+			return nil, nil
+		}
+		value = v
+	}
+
+	switch {
+	case body.contentType == "application/x-www-form-urlencoded":
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return strings.NewReader(httptool.FormatValue(value)), nil
+		}
+		form := url.Values{}
+		for key, v := range fields {
+			form.Set(key, httptool.FormatValue(v))
+		}
+		return strings.NewReader(form.Encode()), nil
+	case isJSON(body.contentType):
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode request body: %w", err)
+		}
+		return bytes.NewReader(data), nil
+	default:
+		return strings.NewReader(httptool.FormatValue(value)), nil
+	}
+}
@@ -0,0 +1,191 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package rest
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// pathParams matches the {name} placeholders of a path template
+var pathParams = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// Config describes a REST API and the endpoints exposed as tools
+type Config struct {
+	BaseURL    string            `json:"base_url" yaml:"base_url"`
+	AuthHeader string            `json:"auth_header,omitempty" yaml:"auth_header,omitempty"` // e.g. "X-API-Key" or "Authorization"
+	AuthKey    string            `json:"auth_key,omitempty" yaml:"auth_key,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"` // Sent with every request
+	Timeout    string            `json:"timeout,omitempty" yaml:"timeout,omitempty"` // Per attempt, e.g. "30s" (default: 30s)
+	Retries    int               `json:"retries,omitempty" yaml:"retries,omitempty"` // Retries of idempotent requests (default: 0)
+	Endpoints  []Endpoint        `json:"endpoints" yaml:"endpoints"`
+}
+
+// Endpoint describes one API call exposed as a tool
+type Endpoint struct {
+	Name        string            `json:"name" yaml:"name"`
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Method      string            `json:"method,omitempty" yaml:"method,omitempty"` // Default: GET
+	Path        string            `json:"path" yaml:"path"`                         // Relative to the base URL, e.g. /widgets/{id}
+	Parameters  []Param           `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Body        string            `json:"body,omitempty" yaml:"body,omitempty"`         // Body encoding: "json" (default) or "form"
+	Response    string            `json:"response,omitempty" yaml:"response,omitempty"` // JSONPath applied to the response, e.g. $.data.items[*].name
+	Headers     map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Hints       *Hints            `json:"hints,omitempty" yaml:"hints,omitempty"`   // Overrides the hints derived from the method
+	Scopes      []string          `json:"scopes,omitempty" yaml:"scopes,omitempty"` // Scopes the caller must have to use the tool
+	Roles       []string          `json:"roles,omitempty" yaml:"roles,omitempty"`   // Roles of which the caller must have one
+}
+
+// Param describes a tool parameter and where it goes in the request
+type Param struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Type        string `json:"type,omitempty" yaml:"type,omitempty"`   // string (default), number, integer, boolean, array or object
+	Items       string `json:"items,omitempty" yaml:"items,omitempty"` // Item type of an array (default: string)
+	Required    bool   `json:"required,omitempty" yaml:"required,omitempty"`
+	Enum        []any  `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Default     any    `json:"default,omitempty" yaml:"default,omitempty"`
+
+	// In is "path", "query", "header" or "body". The default is "path" for names in the path
+	// template, "query" for GET, HEAD, OPTIONS and DELETE, and "body" otherwise.
+	In string `json:"in,omitempty" yaml:"in,omitempty"`
+
+	// Field is the name in the request, if different. For JSON body parameters it may be a
+	// dotted path such as "widget.name".
+	Field string `json:"field,omitempty" yaml:"field,omitempty"`
+}
+
+// Hints overrides the tool hints of an endpoint
+type Hints struct {
+	ReadOnly    *bool `json:"read_only,omitempty" yaml:"read_only,omitempty"`
+	Destructive *bool `json:"destructive,omitempty" yaml:"destructive,omitempty"`
+	Idempotent  *bool `json:"idempotent,omitempty" yaml:"idempotent,omitempty"`
+	OpenWorld   *bool `json:"open_world,omitempty" yaml:"open_world,omitempty"`
+}
+
+// Parse decodes a configuration in YAML or JSON. Unknown fields are rejected, and ${VAR}
+// references in the base URL, auth key and headers are replaced from the environment.
+func Parse(data []byte) (Config, error) {
+	var config Config
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil {
+		return Config{}, fmt.Errorf("unable to parse REST configuration: %w", err)
+	}
+
+	config.BaseURL = os.ExpandEnv(config.BaseURL)
+	config.AuthKey = os.ExpandEnv(config.AuthKey)
+	for name, value := range config.Headers {
+		config.Headers[name] = os.ExpandEnv(value)
+	}
+	for i := range config.Endpoints {
+		for name, value := range config.Endpoints[i].Headers {
+			config.Endpoints[i].Headers[name] = os.ExpandEnv(value)
+		}
+	}
+	return config, nil
+}
+
+// timeout returns the configured request timeout
+func (c *Config) timeout() (time.Duration, error) {
+	if c.Timeout == "" {
+		return 30 * time.Second, nil
+	}
+	timeout, err := time.ParseDuration(c.Timeout)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout '%s'", c.Timeout)
+	}
+	return timeout, nil
+}
+
+// normalize fills in endpoint defaults and checks the endpoint for errors
+func (e *Endpoint) normalize() error {
+	if e.Name == "" {
+		return fmt.Errorf("endpoint %s has no name", e.Path)
+	}
+	e.Method = strings.ToUpper(e.Method)
+	if e.Method == "" {
+		e.Method = http.MethodGet
+	}
+	switch e.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPost,
+		http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return fmt.Errorf("endpoint '%s' has unsupported method '%s'", e.Name, e.Method)
+	}
+	if !strings.HasPrefix(e.Path, "/") {
+		return fmt.Errorf("endpoint '%s' path must start with '/'", e.Name)
+	}
+	switch e.Body {
+	case "":
+		e.Body = "json"
+	case "json", "form":
+	default:
+		return fmt.Errorf("endpoint '%s' has unknown body encoding '%s'", e.Name, e.Body)
+	}
+
+	// Placeholders in the path template are required path parameters, declared or not
+	inPath := make(map[string]bool)
+	for _, match := range pathParams.FindAllStringSubmatch(e.Path, -1) {
+		inPath[match[1]] = true
+	}
+
+	names := make(map[string]bool)
+	for i := range e.Parameters {
+		p := &e.Parameters[i]
+		if p.Name == "" {
+			return fmt.Errorf("endpoint '%s' has a parameter with no name", e.Name)
+		}
+		if names[p.Name] {
+			return fmt.Errorf("endpoint '%s' parameter '%s' is declared more than once", e.Name, p.Name)
+		}
+		names[p.Name] = true
+
+		if p.Field == "" {
+			p.Field = p.Name
+		}
+		if p.In == "" {
+			p.In = defaultLocation(e.Method, inPath[p.Field])
+		}
+		switch p.In {
+		case "path":
+			if !inPath[p.Field] {
+				return fmt.Errorf("endpoint '%s' path parameter '%s' is not in the path", e.Name, p.Name)
+			}
+			p.Required = true
+			delete(inPath, p.Field)
+		case "query", "header", "body":
+		default:
+			return fmt.Errorf("endpoint '%s' parameter '%s' has unknown location '%s'", e.Name, p.Name, p.In)
+		}
+		if p.Type == "" {
+			p.Type = "string"
+		}
+	}
+	for name := range inPath {
+		e.Parameters = append(e.Parameters, Param{Name: name, Field: name, In: "path", Type: "string", Required: true})
+	}
+	return nil
+}
+
+// defaultLocation returns where a parameter goes if the configuration does not say
+func defaultLocation(method string, inPath bool) string {
+	switch {
+	case inPath:
+		return "path"
+	case method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions || method == http.MethodDelete:
+		return "query"
+	default:
+		return "body"
+	}
+}
@@ -0,0 +1,138 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package rest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is a compiled JSONPath expression. The supported subset is the root ($), child
+// names (.name or ['name']), array indexes ([0], [-1]) and wildcards (.* or [*]).
+type jsonPath struct {
+	expression string
+	steps      []pathStep
+	multiple   bool // The expression contains a wildcard and selects a list
+}
+
+// pathStep is one step of a JSONPath expression
+type pathStep struct {
+	name     string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// compileJSONPath parses a JSONPath expression
+func compileJSONPath(expression string) (*jsonPath, error) {
+	if !strings.HasPrefix(expression, "$") {
+		return nil, fmt.Errorf("JSONPath '%s' must start with '$'", expression)
+	}
+	path := &jsonPath{expression: expression}
+
+	rest := expression[1:]
+	for rest != "" {
+		var step pathStep
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			return nil, fmt.Errorf("JSONPath '%s': recursive descent is not supported", expression)
+
+		case rest[0] == '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			name := rest[1 : end+1]
+			if name == "" {
+				return nil, fmt.Errorf("JSONPath '%s': empty name", expression)
+			}
+			step = pathStep{name: name, wildcard: name == "*"}
+			rest = rest[end+1:]
+
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("JSONPath '%s': missing ']'", expression)
+			}
+			selector := rest[1:end]
+			switch {
+			case selector == "*":
+				step = pathStep{wildcard: true}
+			case len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0]:
+				step = pathStep{name: selector[1 : len(selector)-1]}
+			default:
+				index, err := strconv.Atoi(selector)
+				if err != nil {
+					return nil, fmt.Errorf("JSONPath '%s': unsupported selector '[%s]'", expression, selector)
+				}
+				step = pathStep{index: index, isIndex: true}
+			}
+			rest = rest[end+1:]
+
+		default:
+			return nil, fmt.Errorf("JSONPath '%s': unexpected '%c'", expression, rest[0])
+		}
+
+		path.multiple = path.multiple || step.wildcard
+		path.steps = append(path.steps, step)
+	}
+	return path, nil
+}
+
+// evaluate applies the expression to a decoded JSON document. An expression with a wildcard
+// returns a (possibly empty) list; otherwise it returns the single value selected, or an
+// error if nothing matches.
+func (p *jsonPath) evaluate(document any) (any, error) {
+	nodes := []any{document}
+	for _, step := range p.steps {
+		var next []any
+		for _, node := range nodes {
+			switch value := node.(type) {
+			case map[string]any:
+				if step.wildcard {
+					// Sorted by key so the result is stable
+					keys := make([]string, 0, len(value))
+					for key := range value {
+						keys = append(keys, key)
+					}
+					sort.Strings(keys)
+					for _, key := range keys {
+						next = append(next, value[key])
+					}
+				} else if child, ok := value[step.name]; ok && !step.isIndex {
+					next = append(next, child)
+				}
+			case []any:
+				switch {
+				case step.wildcard:
+					next = append(next, value...)
+				case step.isIndex:
+					index := step.index
+					if index < 0 {
+						index += len(value)
+					}
+					if index >= 0 && index < len(value) {
+						next = append(next, value[index])
+					}
+				}
+			}
+		}
+		nodes = next
+	}
+
+	if p.multiple {
+		if nodes == nil {
+			nodes = []any{}
+		}
+		return nodes, nil
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("JSONPath '%s' matched nothing", p.expression)
+	}
+	return nodes[0], nil
+}
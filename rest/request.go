@@ -0,0 +1,224 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PivotLLM/MCPLaunchPad/internal/httptool"
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// endpoint is a configured endpoint with its compiled response expression
+type endpoint struct {
+	provider *Provider
+	config   Endpoint
+	response *jsonPath
+}
+
+// toolDefinition creates the tool that calls the endpoint
+func (e *endpoint) toolDefinition() mcptypes.ToolDefinition {
+	params := make([]*mcptypes.Parameter, 0, len(e.config.Parameters))
+	for _, p := range e.config.Parameters {
+		param := &mcptypes.Parameter{
+			Name:        p.Name,
+			Description: p.Description,
+			Required:    p.Required,
+			Type:        p.Type,
+			Enum:        p.Enum,
+			Default:     p.Default,
+		}
+		if p.Type == "array" {
+			items := p.Items
+			if items == "" {
+				items = "string"
+			}
+			param.Items = &mcptypes.Parameter{Type: items}
+		}
+		params = append(params, param)
+	}
+
+	return mcptypes.ToolDefinition{
+		Name:        e.config.Name,
+		Description: e.config.Description,
+		Parameters:  params,
+		Hints:       e.hints(),
+		Scopes:      e.config.Scopes,
+		Roles:       e.config.Roles,
+		HandlerResult: func(ctx context.Context, _ mcptypes.ToolRequest, options map[string]any) (*mcptypes.ToolResult, error) {
+			return e.call(ctx, options)
+		},
+	}
+}
+
+// hints derives the tool hints from the method, overridden by the hints in the configuration
+func (e *endpoint) hints() *mcptypes.ToolHints {
+	hints := httptool.MethodHints(e.config.Method)
+	if override := e.config.Hints; override != nil {
+		if override.ReadOnly != nil {
+			hints.ReadOnlyHint = override.ReadOnly
+		}
+		if override.Destructive != nil {
+			hints.DestructiveHint = override.Destructive
+		}
+		if override.Idempotent != nil {
+			hints.IdempotentHint = override.Idempotent
+		}
+		if override.OpenWorld != nil {
+			hints.OpenWorldHint = override.OpenWorld
+		}
+	}
+	return hints
+}
+
+// call performs the request. A configured response expression is applied to successful,
+// complete responses.
+func (e *endpoint) call(ctx context.Context, options map[string]any) (*mcptypes.ToolResult, error) {
+	target, header, body, err := e.buildRequest(options)
+	if err != nil {
+		return nil, err
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, e.config.Method, target, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s request: %w", e.config.Method, err)
+	}
+	req.Header = header
+
+	resp, err := e.provider.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", e.config.Method, e.config.Path, err)
+	}
+	if !resp.OK() {
+		return resp.ErrorResult(), nil
+	}
+
+	if e.response != nil && !resp.Truncated {
+		text, err := e.extract(resp.Body)
+		if err != nil {
+			return httptool.ErrorResult(err.Error()), nil
+		}
+		return mcptypes.NewToolResult(mcptypes.NewTextContent(text)), nil
+	}
+	return resp.TextResult(), nil
+}
+
+// buildRequest returns the URL, headers and body of a request built from the tool arguments
+func (e *endpoint) buildRequest(options map[string]any) (string, http.Header, []byte, error) {
+	p := e.provider
+	path := e.config.Path
+	query := url.Values{}
+	header := http.Header{}
+	for name, value := range p.config.Headers {
+		header.Set(name, value)
+	}
+	for name, value := range e.config.Headers {
+		header.Set(name, value)
+	}
+	header.Set("Accept", "application/json, */*;q=0.8")
+
+	var fields map[string]any
+	for _, param := range e.config.Parameters {
+		value, ok := options[param.Name]
+		if !ok || value == nil {
+			if param.Required {
+				return "", nil, nil, fmt.Errorf("missing required parameter: %s", param.Name)
+			}
+			continue
+		}
+
+		switch param.In {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+param.Field+"}", url.PathEscape(httptool.FormatValue(value)))
+		case "query":
+			if list, ok := value.([]any); ok {
+				for _, item := range list {
+					query.Add(param.Field, httptool.FormatValue(item))
+				}
+			} else {
+				query.Set(param.Field, httptool.FormatValue(value))
+			}
+		case "header":
+			header.Set(param.Field, httptool.FormatValue(value))
+		case "body":
+			if fields == nil {
+				fields = make(map[string]any)
+			}
+			if e.config.Body == "json" {
+				setField(fields, strings.Split(param.Field, "."), value)
+			} else {
+				fields[param.Field] = value
+			}
+		}
+	}
+
+	target, err := httptool.ResolveURL(p.config.BaseURL, path, query)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	var body []byte
+	if fields != nil {
+		if e.config.Body == "form" {
+			form := url.Values{}
+			for key, value := range fields {
+				form.Set(key, httptool.FormatValue(value))
+			}
+			body = []byte(form.Encode())
+			header.Set("Content-Type", "application/x-www-form-urlencoded")
+		} else {
+			if body, err = json.Marshal(fields); err != nil {
+				return "", nil, nil, fmt.Errorf("unable to encode request body: %w", err)
+			}
+			header.Set("Content-Type", "application/json")
+		}
+	}
+	return target, header, body, nil
+}
+
+// extract applies the response expression to a JSON response. A string result is returned
+// as is and anything else as JSON.
+func (e *endpoint) extract(data []byte) (string, error) {
+	var document any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return "", fmt.Errorf("response is not JSON: %w", err)
+	}
+	value, err := e.response.evaluate(document)
+	if err != nil {
+		return "", err
+	}
+	if text, ok := value.(string); ok {
+		return text, nil
+	}
+	text, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode extracted value: %w", err)
+	}
+	return string(text), nil
+}
+
+// setField stores value at a dotted path within a JSON object, creating nested objects
+func setField(fields map[string]any, path []string, value any) {
+	for _, name := range path[:len(path)-1] {
+		child, ok := fields[name].(map[string]any)
+		if !ok {
+			child = make(map[string]any)
+			fields[name] = child
+		}
+		fields = child
+	}
+	fields[path[len(path)-1]] = value
+}
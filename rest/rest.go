@@ -0,0 +1,209 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+// Package rest provides a tool provider for REST APIs without an OpenAPI document. Each
+// endpoint in a declarative configuration (YAML or JSON) becomes a tool.
+package rest
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/PivotLLM/MCPLaunchPad/internal/httptool"
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+)
+
+// Ensure Provider implements the mcptypes.ToolProvider interface
+var _ mcptypes.ToolProvider = (*Provider)(nil)
+
+// Option defines a function type for configuring the Provider
+type Option func(*Provider)
+
+// Auth authenticates each request to the API, replacing the configured auth header
+type Auth = httptool.Auth
+
+// Authentication schemes for WithAuth, the same as those of the openapi package
+var (
+	BearerAuth      = httptool.BearerAuth      // Static token in the Authorization header
+	TokenAuth       = httptool.TokenAuth       // Token obtained for each request, in the Authorization header
+	APIKeyAuth      = httptool.APIKeyAuth      // API key in the named header
+	APIKeyQueryAuth = httptool.APIKeyQueryAuth // API key as the named query parameter
+	BasicAuth       = httptool.BasicAuth       // HTTP basic authentication
+)
+
+// Provider is a tool provider with one tool per configured endpoint. All tools share one
+// http.Client.
+type Provider struct {
+	config          Config
+	logger          mcptypes.Logger
+	httpClient      *http.Client
+	auth            Auth
+	retries         int
+	retryWait       time.Duration
+	maxResponseSize int64
+
+	client    *httptool.Client
+	endpoints []*endpoint
+}
+
+// WithLogger sets the logger
+func WithLogger(logger mcptypes.Logger) Option {
+	return func(p *Provider) {
+		p.logger = logger
+	}
+}
+
+// WithHTTPClient sets the HTTP client shared by all endpoints, replacing the default client
+// built from the configured timeout
+func WithHTTPClient(client *http.Client) Option {
+	return func(p *Provider) {
+		p.httpClient = client
+	}
+}
+
+// WithBaseURL overrides the base URL of the configuration
+func WithBaseURL(baseURL string) Option {
+	return func(p *Provider) {
+		p.config.BaseURL = baseURL
+	}
+}
+
+// WithAuthHeader overrides the auth header name and value of the configuration
+func WithAuthHeader(header, key string) Option {
+	return func(p *Provider) {
+		p.config.AuthHeader = header
+		p.config.AuthKey = key
+	}
+}
+
+// WithAuth sets a function that authenticates each request, used instead of the auth header
+func WithAuth(auth Auth) Option {
+	return func(p *Provider) {
+		p.auth = auth
+	}
+}
+
+// WithRetries overrides the number of retries of idempotent requests and sets the initial
+// delay between them, which doubles after each attempt (default: 500ms)
+func WithRetries(retries int, wait time.Duration) Option {
+	return func(p *Provider) {
+		p.retries = retries
+		p.retryWait = wait
+	}
+}
+
+// WithMaxResponseSize sets the largest response body returned to the client; longer
+// responses are truncated (default: 1 MiB)
+func WithMaxResponseSize(size int64) Option {
+	return func(p *Provider) {
+		p.maxResponseSize = size
+	}
+}
+
+// New creates a provider from a configuration
+func New(config Config, options ...Option) (*Provider, error) {
+	p := &Provider{
+		config:          config,
+		retries:         -1,
+		retryWait:       500 * time.Millisecond,
+		maxResponseSize: 1 << 20,
+	}
+	for _, opt := range options {
+		opt(p)
+	}
+	if p.logger == nil {
+		p.logger = mcptypes.NoopLogger{}
+	}
+	if p.retries < 0 {
+		p.retries = p.config.Retries
+	}
+
+	base, err := url.Parse(p.config.BaseURL)
+	if err != nil || !base.IsAbs() {
+		return nil, fmt.Errorf("invalid base URL '%s'", p.config.BaseURL)
+	}
+
+	if p.httpClient == nil {
+		timeout, err := p.config.timeout()
+		if err != nil {
+			return nil, err
+		}
+		p.httpClient = &http.Client{Timeout: timeout}
+	}
+
+	// The configured auth header is used unless a function was set with WithAuth
+	auth := p.auth
+	if auth == nil && p.config.AuthHeader != "" {
+		auth = httptool.APIKeyAuth(p.config.AuthHeader, p.config.AuthKey)
+	}
+	p.client = &httptool.Client{
+		HTTPClient:      p.httpClient,
+		Auth:            auth,
+		Retries:         p.retries,
+		RetryWait:       p.retryWait,
+		MaxResponseSize: p.maxResponseSize,
+		Logger:          p.logger,
+		Name:            "REST",
+	}
+
+	names := make(map[string]bool)
+	for _, config := range p.config.Endpoints {
+		if err = config.normalize(); err != nil {
+			return nil, err
+		}
+		if names[config.Name] {
+			return nil, fmt.Errorf("endpoint name '%s' is used more than once", config.Name)
+		}
+		names[config.Name] = true
+
+		e := &endpoint{provider: p, config: config}
+		if config.Response != "" {
+			if e.response, err = compileJSONPath(config.Response); err != nil {
+				return nil, fmt.Errorf("endpoint '%s': %w", config.Name, err)
+			}
+		}
+		p.endpoints = append(p.endpoints, e)
+	}
+	return p, nil
+}
+
+// NewFromFile creates a provider from a YAML or JSON configuration file
+func NewFromFile(path string, options ...Option) (*Provider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read REST configuration: %w", err)
+	}
+	config, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return New(config, options...)
+}
+
+// NewFromFS creates a provider from a YAML or JSON configuration in a file system such as an embed.FS
+func NewFromFS(fsys fs.FS, path string, options ...Option) (*Provider, error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read REST configuration: %w", err)
+	}
+	config, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return New(config, options...)
+}
+
+// RegisterTools returns one tool per endpoint
+func (p *Provider) RegisterTools() []mcptypes.ToolDefinition {
+	tools := make([]mcptypes.ToolDefinition, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		tools = append(tools, e.toolDefinition())
+	}
+	return tools
+}
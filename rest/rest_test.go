@@ -0,0 +1,316 @@
+/******************************************************************************
+ * Copyright (c) 2025 Tenebris Technologies Inc.                              *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package rest_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/PivotLLM/MCPLaunchPad/mcptypes"
+	"github.com/PivotLLM/MCPLaunchPad/rest"
+)
+
+// recordingAPI records each request and answers with a fixed body
+type recordingAPI struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   []string
+	body     string // Response body
+}
+
+func (a *recordingAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	a.mu.Lock()
+	a.requests = append(a.requests, r)
+	a.bodies = append(a.bodies, string(body))
+	a.mu.Unlock()
+	_, _ = io.WriteString(w, a.body)
+}
+
+// last returns the last request and its body
+func (a *recordingAPI) last(t *testing.T) (*http.Request, string) {
+	t.Helper()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.requests) == 0 {
+		t.Fatal("API not called")
+	}
+	return a.requests[len(a.requests)-1], a.bodies[len(a.bodies)-1]
+}
+
+// newProvider parses a configuration and creates a provider for the API
+func newProvider(t *testing.T, api *recordingAPI, config string, options ...rest.Option) *rest.Provider {
+	t.Helper()
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	parsed, err := rest.Parse([]byte(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := rest.New(parsed, append([]rest.Option{rest.WithBaseURL(server.URL + "/v1")}, options...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// call calls a tool of the provider with the arguments
+func call(t *testing.T, p *rest.Provider, name string, arguments map[string]any) (*mcptypes.ToolResult, error) {
+	t.Helper()
+	for _, tool := range p.RegisterTools() {
+		if tool.Name == name {
+			return tool.HandlerResult(context.Background(), mcptypes.ToolRequest{}, arguments)
+		}
+	}
+	t.Fatalf("no tool %s", name)
+	return nil, nil
+}
+
+const mappingConfig = `
+headers:
+  X-Client: test
+endpoints:
+  - name: update_widget
+    method: put
+    path: /widgets/{id}
+    headers:
+      X-Endpoint: update
+    parameters:
+      - name: id
+        type: integer
+      - name: dry_run
+        in: query
+        type: boolean
+      - name: tags
+        in: query
+        type: array
+      - name: trace
+        in: header
+        field: X-Trace-Id
+      - name: name
+        field: widget.name
+      - name: size
+        type: number
+        field: widget.dimensions.size
+  - name: submit_form
+    method: POST
+    path: /forms
+    body: form
+    parameters:
+      - name: email
+        required: true
+      - name: count
+        type: integer
+`
+
+func TestRequestMapping(t *testing.T) {
+	api := &recordingAPI{body: "ok"}
+	p := newProvider(t, api, mappingConfig)
+
+	result, err := call(t, p, "update_widget", map[string]any{
+		"id":      float64(42),
+		"dry_run": true,
+		"tags":    []any{"a", "b"},
+		"trace":   "t-1",
+		"name":    "gear",
+		"size":    2.5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.IsError || result.Content[0].Text != "ok" {
+		t.Errorf("result = %+v, want ok", result)
+	}
+
+	r, body := api.last(t)
+	checks := []struct {
+		name, got, want string
+	}{
+		{"method", r.Method, http.MethodPut},
+		{"path", r.URL.Path, "/v1/widgets/42"},
+		{"query", r.URL.RawQuery, "dry_run=true&tags=a&tags=b"},
+		{"header parameter", r.Header.Get("X-Trace-Id"), "t-1"},
+		{"configuration header", r.Header.Get("X-Client"), "test"},
+		{"endpoint header", r.Header.Get("X-Endpoint"), "update"},
+		{"content type", r.Header.Get("Content-Type"), "application/json"},
+		{"body", body, `{"widget":{"dimensions":{"size":2.5},"name":"gear"}}`},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %q, want %q", c.name, c.got, c.want)
+		}
+	}
+
+	if _, err = call(t, p, "submit_form", map[string]any{"email": "a@example.com", "count": float64(3)}); err != nil {
+		t.Fatal(err)
+	}
+	r, body = api.last(t)
+	if ct := r.Header.Get("Content-Type"); ct != "application/x-www-form-urlencoded" {
+		t.Errorf("form content type = %q", ct)
+	}
+	if body != "count=3&email=a%40example.com" {
+		t.Errorf("form body = %q", body)
+	}
+}
+
+func TestMissingRequiredParameter(t *testing.T) {
+	api := &recordingAPI{body: "ok"}
+	p := newProvider(t, api, mappingConfig)
+
+	tests := []struct {
+		tool      string
+		arguments map[string]any
+		missing   string
+	}{
+		{"update_widget", map[string]any{"name": "gear"}, "id"}, // Path parameters are always required
+		{"submit_form", map[string]any{"count": float64(1)}, "email"},
+		{"submit_form", map[string]any{"email": nil}, "email"},
+	}
+	for _, tt := range tests {
+		_, err := call(t, p, tt.tool, tt.arguments)
+		if err == nil || !strings.Contains(err.Error(), "missing required parameter: "+tt.missing) {
+			t.Errorf("%s(%v) error = %v, want missing %s", tt.tool, tt.arguments, err, tt.missing)
+		}
+	}
+	if len(api.requests) != 0 {
+		t.Errorf("API called %d times with missing parameters", len(api.requests))
+	}
+}
+
+func TestResponseExtraction(t *testing.T) {
+	const document = `{"data": {"total": 2, "items": [{"name": "a", "size": 1}, {"name": "b", "size": 2}]}}`
+
+	tests := []struct {
+		name     string
+		response string
+		body     string
+		want     string
+		isError  bool
+	}{
+		{"string", "$.data.items[0].name", document, "a", false},
+		{"number", "$.data.total", document, "2", false},
+		{"last item", "$.data.items[-1]", document, `{"name":"b","size":2}`, false},
+		{"wildcard", "$.data.items[*].name", document, `["a","b"]`, false},
+		{"bracket names", "$['data']['items'][1]['size']", document, "2", false},
+		{"no match", "$.data.missing", document, "JSONPath '$.data.missing' matched nothing", true},
+		{"empty wildcard", "$.data.missing[*]", document, "[]", false},
+		{"not JSON", "$.data", "<html>oops</html>", "response is not JSON", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &recordingAPI{body: tt.body}
+			p := newProvider(t, api, `
+endpoints:
+  - name: list
+    path: /items
+    response: "`+tt.response+`"
+`)
+			result, err := call(t, p, "list", map[string]any{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			text := result.Content[0].Text
+			if result.IsError != tt.isError || !strings.HasPrefix(text, tt.want) {
+				t.Errorf("result = %q (error %t), want %q (error %t)", text, result.IsError, tt.want, tt.isError)
+			}
+		})
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{"unknown field", "endpoints:\n  - name: a\n    path: /a\n    verb: GET", "field verb not found"},
+		{"no name", "endpoints:\n  - path: /a", "has no name"},
+		{"relative path", "endpoints:\n  - name: a\n    path: a", "must start with '/'"},
+		{"unsupported method", "endpoints:\n  - name: a\n    path: /a\n    method: TRACE", "unsupported method"},
+		{"unknown body encoding", "endpoints:\n  - name: a\n    path: /a\n    body: xml", "unknown body encoding"},
+		{"duplicate endpoint", "endpoints:\n  - name: a\n    path: /a\n  - name: a\n    path: /b", "used more than once"},
+		{"duplicate parameter", "endpoints:\n  - name: a\n    path: /a\n    parameters: [{name: x}, {name: x}]", "declared more than once"},
+		{"path parameter not in path", "endpoints:\n  - name: a\n    path: /a\n    parameters: [{name: x, in: path}]", "is not in the path"},
+		{"unknown location", "endpoints:\n  - name: a\n    path: /a\n    parameters: [{name: x, in: cookie}]", "unknown location"},
+		{"invalid JSONPath", "endpoints:\n  - name: a\n    path: /a\n    response: data", "must start with '$'"},
+		{"recursive descent", "endpoints:\n  - name: a\n    path: /a\n    response: $..name", "not supported"},
+		{"invalid timeout", "timeout: soon\nendpoints: []", "invalid timeout"},
+		{"invalid base URL", "base_url: /relative\nendpoints: []", "invalid base URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := rest.Parse([]byte(tt.config))
+			if err == nil {
+				if config.BaseURL == "" {
+					config.BaseURL = "https://api.example.com"
+				}
+				_, err = rest.New(config)
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseExpandsEnvironment(t *testing.T) {
+	t.Setenv("REST_TEST_KEY", "k1")
+	config, err := rest.Parse([]byte("base_url: https://api.example.com\nauth_key: ${REST_TEST_KEY}\nheaders: {X-Key: $REST_TEST_KEY}\nendpoints: []"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.AuthKey != "k1" || config.Headers["X-Key"] != "k1" {
+		t.Errorf("auth key %q and header %q not expanded", config.AuthKey, config.Headers["X-Key"])
+	}
+}
+
+// TestExampleWidgets checks the configuration example1 uses for its widget API
+func TestExampleWidgets(t *testing.T) {
+	api := &recordingAPI{body: `{"id": "w1"}`}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	p, err := rest.NewFromFile("../example1/widgets.yaml",
+		rest.WithBaseURL(server.URL),
+		rest.WithAuthHeader("X-API-Key", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, tool := range p.RegisterTools() {
+		names = append(names, tool.Name)
+	}
+	if got := strings.Join(names, " "); got != "list_widgets create_widget get_widget delete_widget" {
+		t.Errorf("tools = %s", got)
+	}
+
+	if _, err = call(t, p, "create_widget", map[string]any{"name": "gear", "description": "A gear"}); err != nil {
+		t.Fatal(err)
+	}
+	r, body := api.last(t)
+	if r.Method != http.MethodPost || r.URL.Path != "/widget" || r.Header.Get("X-API-Key") != "secret" {
+		t.Errorf("request = %s %s with key %q", r.Method, r.URL.Path, r.Header.Get("X-API-Key"))
+	}
+	if body != `{"description":"A gear","name":"gear"}` {
+		t.Errorf("body = %s", body)
+	}
+
+	if _, err = call(t, p, "list_widgets", map[string]any{"offset": float64(10), "limit": float64(5)}); err != nil {
+		t.Fatal(err)
+	}
+	if r, _ = api.last(t); r.URL.RawQuery != "limit=5&offset=10" {
+		t.Errorf("query = %s", r.URL.RawQuery)
+	}
+}